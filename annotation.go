@@ -0,0 +1,114 @@
+package charts
+
+import (
+	"github.com/midbel/svg"
+)
+
+type AnnotationType int
+
+const (
+	AnnotateHLine AnnotationType = iota
+	AnnotateVLine
+	AnnotateHSpan
+	AnnotateVSpan
+	AnnotatePoint
+)
+
+// Annotation marks a value, a range of values or a point of a chart. The
+// lines and the point are drawn with the line color of the style, the spans
+// are filled with the first color of its fill list.
+type Annotation[T, U ScalerConstraint] struct {
+	Type  AnnotationType
+	X     T
+	X2    T
+	Y     U
+	Y2    U
+	Text  string
+	Style Style
+}
+
+func (a Annotation[T, U]) Render(x Scaler[T], y Scaler[U], width, height float64) svg.Element {
+	var (
+		grp = classGroup("annotation")
+		txt svg.Element
+	)
+	switch a.Type {
+	case AnnotateHLine:
+		pos := annotationPos(y, a.Y)
+		grp.Append(a.line(0, pos, width, pos))
+		txt = a.text(width-annotationMargin, pos-annotationMargin, "end", "auto")
+	case AnnotateVLine:
+		pos := annotationPos(x, a.X)
+		grp.Append(a.line(pos, 0, pos, height))
+		txt = a.text(pos+annotationMargin, annotationMargin, "start", "hanging")
+	case AnnotateHSpan:
+		fst, lst := annotationSpan(y, a.Y, a.Y2)
+		grp.Append(a.rect(0, fst, width, lst-fst))
+		txt = a.text(annotationMargin, fst+annotationMargin, "start", "hanging")
+	case AnnotateVSpan:
+		fst, lst := annotationSpan(x, a.X, a.X2)
+		grp.Append(a.rect(fst, 0, lst-fst, height))
+		txt = a.text(fst+annotationMargin, annotationMargin, "start", "hanging")
+	case AnnotatePoint:
+		var (
+			px = annotationPos(x, a.X)
+			py = annotationPos(y, a.Y)
+		)
+		grp.Append(GetCircle(svg.NewPos(px, py), DefaultSize*1.5, a.Style.LineColor))
+		txt = a.text(px+annotationMargin, py-annotationMargin, "start", "auto")
+	default:
+		return nil
+	}
+	if txt != nil {
+		grp.Append(txt)
+	}
+	return grp.AsElement()
+}
+
+const annotationMargin = 4
+
+func (a Annotation[T, U]) line(x1, y1, x2, y2 float64) svg.Element {
+	pat := a.Style.linePath()
+	pat.AbsMoveTo(svg.NewPos(x1, y1))
+	pat.AbsLineTo(svg.NewPos(x2, y2))
+	return pat.AsElement()
+}
+
+func (a Annotation[T, U]) rect(x, y, w, h float64) svg.Element {
+	style := a.Style
+	style.LineWidth = 0
+	rec := style.Rect(w, h)
+	rec.Pos = svg.NewPos(x, y)
+	return rec.AsElement()
+}
+
+func (a Annotation[T, U]) text(x, y float64, anchor, baseline string) svg.Element {
+	if a.Text == "" {
+		return nil
+	}
+	txt := a.Style.Text(a.Text)
+	txt.Pos = svg.NewPos(x, y)
+	txt.Anchor = anchor
+	txt.Baseline = baseline
+	return txt.AsElement()
+}
+
+// annotationPos gives the position of a value on the axis. Values of a
+// category axis are placed at the middle of their band.
+func annotationPos[T ScalerConstraint](scale Scaler[T], value T) float64 {
+	pos := scale.Scale(value)
+	if _, ok := any(value).(string); ok {
+		pos += scale.Space() / 2
+	}
+	return pos
+}
+
+// annotationSpan gives the positions of the bounds of a range of values. The
+// range of a category axis covers the full bands of its bounds.
+func annotationSpan[T ScalerConstraint](scale Scaler[T], fst, lst T) (float64, float64) {
+	lo, hi := orderBounds(scale.Scale(fst), scale.Scale(lst))
+	if _, ok := any(fst).(string); ok {
+		hi += scale.Space()
+	}
+	return lo, hi
+}
@@ -39,6 +39,7 @@ type Axis[T ScalerConstraint] struct {
 	WithOuterTicks bool
 	WithBands      bool
 	WithArrow      bool
+	WithNiceTicks  bool
 }
 
 func (a Axis[T]) Render(length, size, left, top float64) svg.Element {
@@ -61,7 +62,11 @@ func (a Axis[T]) Render(length, size, left, top float64) svg.Element {
 		offset = a.Scaler.Space() / 2
 	}
 	if len(data) == 0 {
-		data = a.Scaler.Values(a.Ticks)
+		if a.WithNiceTicks {
+			data = niceValues(a.Scaler, a.Ticks)
+		} else {
+			data = a.Scaler.Values(a.Ticks)
+		}
 	}
 	if a.Format == nil {
 		a.Format = defaultLabelFormat[T]
@@ -158,7 +163,7 @@ func lineTick(orient Orientation, offset, size float64, stroke svg.Stroke) svg.L
 }
 
 func axisText(orient Orientation, str string, x, y float64, font svg.Font) svg.Text {
-	txt := svg.NewText(str)
+	txt := newText(str)
 	txt.Font = font
 	txt.Anchor = "middle"
 	txt.Baseline = "auto"
@@ -200,7 +205,7 @@ func tickText(orient Orientation, str string, offset, rotate float64, font svg.F
 		y = -y
 	default:
 	}
-	txt := svg.NewText(str)
+	txt := newText(str)
 	txt.Pos = svg.NewPos(x, y)
 	txt.Font = font
 	txt.Anchor = anchor
@@ -0,0 +1,115 @@
+package charts
+
+import (
+	"math"
+)
+
+type BinStrategy int
+
+const (
+	BinSturges BinStrategy = iota
+	BinWidth
+	BinCount
+	BinScott
+	BinFreedman
+)
+
+type BinMode int
+
+const (
+	BinFrequency BinMode = iota
+	BinDensity
+	BinCumulative
+)
+
+type Bin struct {
+	Min   float64
+	Max   float64
+	Count int
+	Value float64
+}
+
+type Binning struct {
+	Strategy BinStrategy
+	Width    float64
+	Count    int
+	Mode     BinMode
+}
+
+// Bins distributes the values into bins of equal width. The value of each bin
+// depends on the mode: the number of values in the bin, the density (so that
+// the total area of the bins is 1) or the number of values up to the bin.
+// NaN values are ignored.
+func (b Binning) Bins(values []float64) []Bin {
+	values = sortedValues(values)
+	if len(values) == 0 {
+		return nil
+	}
+	var (
+		fst   = values[0]
+		lst   = values[len(values)-1]
+		width = 1.0
+		count = 1
+	)
+	if fst == lst {
+		fst -= width / 2
+	} else {
+		width = b.binWidth(values)
+		count = int(math.Ceil((lst-fst)/width - 1e-9))
+		if count < 1 {
+			count = 1
+		}
+	}
+
+	bins := make([]Bin, count)
+	for i := range bins {
+		bins[i].Min = fst + width*float64(i)
+		bins[i].Max = fst + width*float64(i+1)
+	}
+	for _, v := range values {
+		x := int((v - fst) / width)
+		if x >= count {
+			x = count - 1
+		}
+		bins[x].Count++
+	}
+	var total int
+	for i := range bins {
+		total += bins[i].Count
+		switch b.Mode {
+		case BinDensity:
+			bins[i].Value = float64(bins[i].Count) / (float64(len(values)) * width)
+		case BinCumulative:
+			bins[i].Value = float64(total)
+		default:
+			bins[i].Value = float64(bins[i].Count)
+		}
+	}
+	return bins
+}
+
+func (b Binning) binWidth(values []float64) float64 {
+	var (
+		n    = float64(len(values))
+		diff = values[len(values)-1] - values[0]
+	)
+	switch b.Strategy {
+	case BinWidth:
+		if b.Width > 0 {
+			return b.Width
+		}
+	case BinCount:
+		if b.Count > 0 {
+			return diff / float64(b.Count)
+		}
+	case BinScott:
+		if dev := stdDev(values); dev > 0 {
+			return 3.49 * dev * math.Pow(n, -1.0/3)
+		}
+	case BinFreedman:
+		if iqr := quantile(values, 0.75) - quantile(values, 0.25); iqr > 0 {
+			return 2 * iqr * math.Pow(n, -1.0/3)
+		}
+	}
+	return diff / (math.Ceil(math.Log2(n)) + 1)
+}
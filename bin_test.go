@@ -0,0 +1,64 @@
+package charts
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBinningBins(t *testing.T) {
+	values := []float64{1, 2, 2, 3, 3, 3, 4, 4, 5, 9}
+	data := []struct {
+		Binning
+		Count  int
+		Values []float64
+	}{
+		{
+			Binning: Binning{Strategy: BinWidth, Width: 2},
+			Count:   4,
+			Values:  []float64{3, 5, 1, 1},
+		},
+		{
+			Binning: Binning{Strategy: BinCount, Count: 2},
+			Count:   2,
+			Values:  []float64{8, 2},
+		},
+		{
+			Binning: Binning{Strategy: BinCount, Count: 2, Mode: BinCumulative},
+			Count:   2,
+			Values:  []float64{8, 10},
+		},
+		{
+			Binning: Binning{Strategy: BinSturges},
+			Count:   5,
+			Values:  []float64{3, 5, 1, 0, 1},
+		},
+	}
+	for _, d := range data {
+		bins := d.Bins(values)
+		if len(bins) != d.Count {
+			t.Errorf("%+v: expected %d bins, got %d", d.Binning, d.Count, len(bins))
+			continue
+		}
+		for i := range bins {
+			if bins[i].Value != d.Values[i] {
+				t.Errorf("%+v: bin %d: want %f, got %f", d.Binning, i, d.Values[i], bins[i].Value)
+			}
+		}
+	}
+}
+
+func TestBinningDensity(t *testing.T) {
+	values := []float64{0.5, 1.2, 1.9, 2.2, 3.7, 4.1, 4.4, 6.3, 7.8, 8.1, 9.5, 9.9}
+	for _, s := range []BinStrategy{BinSturges, BinScott, BinFreedman} {
+		var (
+			bin  = Binning{Strategy: s, Mode: BinDensity}
+			area float64
+		)
+		for _, b := range bin.Bins(values) {
+			area += b.Value * (b.Max - b.Min)
+		}
+		if math.Abs(area-1) > 1e-9 {
+			t.Errorf("strategy %d: density should sum to 1, got %f", s, area)
+		}
+	}
+}
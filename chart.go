@@ -2,7 +2,11 @@ package charts
 
 import (
 	"bufio"
+	"fmt"
 	"io"
+	"math"
+	"strconv"
+	"time"
 
 	"github.com/midbel/svg"
 )
@@ -26,9 +30,14 @@ type Chart[T, U ScalerConstraint] struct {
 	Legend struct {
 		Title  string
 		Orient Orientation
+		Cols   int
 	}
 	Center Point[T, U]
 	Theme  string
+
+	Annotations []Annotation[T, U]
+
+	Interactive bool
 }
 
 func (c Chart[T, U]) DrawingWidth() float64 {
@@ -54,13 +63,22 @@ func (c Chart[T, U]) Drawn(set ...Data) svg.Element {
 	}
 
 	el.Append(c.drawAxis())
+	if c.Interactive {
+		set = c.withFormat(set)
+	}
 	for _, s := range set {
 		ar := c.getArea(s)
 		el.Append(ar.AsElement())
 	}
-	// if ld := c.drawLegend(set); ld != nil {
-	// 	el.Append(ld)
-	// }
+	if an := c.drawAnnotations(); an != nil {
+		el.Append(an)
+	}
+	if ld := c.drawLegend(set); ld != nil {
+		el.Append(ld)
+	}
+	if cb := c.drawColorBar(set); cb != nil {
+		el.Append(cb)
+	}
 	if txt := c.drawTitle(); txt != nil {
 		el.Append(txt)
 	}
@@ -83,11 +101,88 @@ func (c Chart[T, U]) getArea(serie Data) svg.Group {
 	}
 	g.Clip = "clip-chart"
 	g.Transform = svg.Translate(c.Padding.Left, c.Padding.Top)
+	if c.Interactive {
+		g.Data = append(g.Data, svg.Datum{Name: "title", Value: dataValue(serie.String())})
+	}
 
 	g.Append(serie.Render())
 	return g
 }
 
+func (c Chart[T, U]) drawAnnotations() svg.Element {
+	var (
+		x = c.Bottom.Scaler
+		y = c.Left.Scaler
+	)
+	if x == nil {
+		x = c.Top.Scaler
+	}
+	if y == nil {
+		y = c.Right.Scaler
+	}
+	if len(c.Annotations) == 0 || x == nil || y == nil {
+		return nil
+	}
+	grp := classGroup("annotations")
+	grp.Clip = "clip-chart"
+	grp.Transform = svg.Translate(c.Padding.Left, c.Padding.Top)
+	for _, a := range c.Annotations {
+		if el := a.Render(x, y, c.DrawingWidth(), c.DrawingHeight()); el != nil {
+			grp.Append(el)
+		}
+	}
+	return grp.AsElement()
+}
+
+func (c Chart[T, U]) withFormat(set []Data) []Data {
+	type formatter interface {
+		withFormat(func(T) string, func(U) string) Data
+	}
+	var (
+		fx   = c.formatX()
+		fy   = c.formatY()
+		list = make([]Data, 0, len(set))
+	)
+	for _, s := range set {
+		if f, ok := s.(formatter); ok {
+			s = f.withFormat(fx, fy)
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+func (c Chart[T, U]) formatX() func(T) string {
+	if c.Bottom.Format != nil {
+		return c.Bottom.Format
+	}
+	if c.Top.Format != nil {
+		return c.Top.Format
+	}
+	return formatValue[T]
+}
+
+func (c Chart[T, U]) formatY() func(U) string {
+	if c.Left.Format != nil {
+		return c.Left.Format
+	}
+	if c.Right.Format != nil {
+		return c.Right.Format
+	}
+	return formatValue[U]
+}
+
+func formatValue[T ScalerConstraint](v T) string {
+	switch v := any(v).(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 func (c Chart[T, U]) getDefs() svg.Element {
 	var defs svg.Defs
 	defs.Append(c.getClip())
@@ -111,7 +206,7 @@ func (c Chart[T, U]) drawTitle() svg.Element {
 	if c.Title == "" {
 		return nil
 	}
-	txt := svg.NewText(c.Title)
+	txt := newText(c.Title)
 	txt.Font = svg.NewFont(FontSize * 1.2)
 	txt.Class = append(txt.Class, "chart-title")
 	txt.Anchor = "middle"
@@ -125,69 +220,217 @@ func (c Chart[T, U]) drawTitle() svg.Element {
 }
 
 func (c Chart[T, U]) drawLegend(series []Data) svg.Element {
+	if c.Legend.Orient == 0 && c.Legend.Title == "" {
+		return nil
+	}
+	var (
+		offset  = FontSize * 1.4
+		swatch  = FontSize * 2
+		chars   = int((LegendWidth - swatch - LegendMargin) / textWidth("x", FontSize))
+		entries = createLegendEntries(series, chars)
+	)
+	if len(entries) == 0 {
+		return nil
+	}
 	var (
-		offset = FontSize * 1.4
-		height = float64(len(series)) * offset
+		cols   = splitLegendColumns(entries, c.legendColumns(entries, offset))
 		width  float64
-		grp    svg.Group
+		height float64
+		top    float64
+		grp    = classGroup("legend")
 	)
+	for _, col := range cols {
+		width += swatch + LegendMargin + columnWidth(col)
+		height = math.Max(height, columnHeight(col, offset))
+	}
+	width += LegendMargin * float64(len(cols)-1)
+
+	var title []string
 	if c.Legend.Title != "" {
-		height += offset
+		n := int(width / textWidth("x", FontSize))
+		if n < chars {
+			n = chars
+		}
+		title = wrapText(c.Legend.Title, n)
+		for _, str := range title {
+			width = math.Max(width, textWidth(str, FontSize))
+		}
+		top = float64(len(title)) * offset
+		height += top
+	}
+
+	var rec svg.Rect
+	rec.Class = append(rec.Class, "legend-box")
+	rec.Pos = svg.NewPos(-LegendMargin, -LegendMargin)
+	rec.Dim = svg.NewDim(width+LegendMargin*2, height+LegendMargin*2)
+	rec.Fill = svg.NewFill("white")
+	rec.Fill.Opacity = 0.8
+	rec.Stroke = svg.NewStroke(ColorBlack, 1)
+	rec.Stroke.Opacity = 0.25
+	grp.Append(rec.AsElement())
+
+	if len(title) > 0 {
+		font := svg.NewFont(FontSize)
+		font.Weight = "bold"
+		txt := multilineText(title, svg.NewPos(0, offset/2), offset, font)
+		txt.Class = append(txt.Class, "legend-title")
+		txt.Baseline = "middle"
+		grp.Append(txt.AsElement())
+	}
+
+	var left float64
+	for _, col := range cols {
+		y := top
+		for _, e := range col {
+			var g svg.Group
+			g.Class = append(g.Class, "legend-item")
+			if c.Interactive && e.ident != "" {
+				g.Data = append(g.Data, svg.Datum{Name: "serie", Value: e.ident})
+			}
+			g.Transform = svg.Translate(left, y)
+
+			var sg svg.Group
+			sg.Transform = svg.Translate(0, offset*0.2)
+			sg.Append(e.Render(swatch, offset*0.6))
+			g.Append(sg.AsElement())
+
+			txt := multilineText(e.lines, svg.NewPos(swatch+LegendMargin, offset/2), offset, svg.NewFont(FontSize))
+			txt.Baseline = "middle"
+			g.Append(txt.AsElement())
+
+			grp.Append(g.AsElement())
+			y += e.height(offset)
+		}
+		left += swatch + LegendMargin*2 + columnWidth(col)
 	}
-	for i, s := range series {
-		title := s.String()
-		if n := float64(len(title)); i == 0 || n > width {
-			width = n
+	x, y := c.legendPosition(width, height)
+	grp.Transform = svg.Translate(x, y)
+	return grp.AsElement()
+}
+
+// drawColorBar draws the color scale of the first serie rendered through one
+// in the right padding of the chart.
+func (c Chart[T, U]) drawColorBar(series []Data) svg.Element {
+	type colorScaler interface {
+		ColorScale() ColorScale
+	}
+	var (
+		scale ColorScale
+		found bool
+	)
+	for _, s := range series {
+		ser, ok := s.(Serie[T, U])
+		if !ok {
+			continue
 		}
-		var g svg.Group
-		g.Transform = svg.Translate(0, float64(i)*offset)
-		// li := svg.NewLine(svg.NewPos(0, 0), svg.NewPos(20, 0))
-		// li.Stroke = svg.NewStroke(s.GetColor(), 4)
-
-		tx := svg.NewText(title)
-		tx.Pos = svg.NewPos(30, 0)
-		tx.Font = svg.NewFont(FontSize)
-		tx.Baseline = "middle"
-
-		// g.Append(li.AsElement())
-		g.Append(tx.AsElement())
-		grp.Append(g.AsElement())
-	}
-	width *= FontSize * 0.4
-
-	var left, top float64
-	switch c.Legend.Orient {
-	case OrientRight:
-		left = c.Width - c.Padding.Left - width
-		top = (c.Height - c.Padding.Top - height) / 2
-	case OrientRight | OrientBottom:
-		left = c.Width - c.Padding.Left - width
-		top = c.Height - c.Padding.Top - height
-	case OrientBottom:
-		left = (c.Width - width) / 2
-		top = c.Height - c.Padding.Top - height
-	case OrientLeft | OrientBottom:
-		left = c.Padding.Left
-		top = c.Height - c.Padding.Top - height
-	case OrientLeft:
-		left = c.Padding.Left
-		top = (c.Height - c.Padding.Vertical() - height) / 2
-	case OrientLeft | OrientTop:
-		top = c.Padding.Top
-		left = c.Padding.Left
-	case OrientTop:
-		left = (c.Width - width) / 2
-		top = c.Padding.Top
-	case OrientRight | OrientTop:
-		top = c.Padding.Top
-		left = c.Width - c.Padding.Left - width
-	default:
+		if r, ok := ser.Renderer.(colorScaler); ok {
+			scale, found = r.ColorScale(), true
+			break
+		}
+	}
+	if !found || len(scale.Colors) == 0 {
 		return nil
 	}
-	grp.Transform = svg.Translate(left, top)
+	var (
+		grp    = classGroup("color-bar")
+		height = c.DrawingHeight()
+		width  = FontSize
+		steps  = 64
+		step   = height / float64(steps)
+		fst    = scale.Min()
+		lst    = scale.Max()
+	)
+	grp.Transform = svg.Translate(c.Width-c.Padding.Right+LegendMargin*2, c.Padding.Top)
+	for i := 0; i < steps; i++ {
+		var (
+			rec svg.Rect
+			val = lst - (lst-fst)*(float64(i)+0.5)/float64(steps)
+		)
+		rec.Pos = svg.NewPos(0, float64(i)*step)
+		rec.Dim = svg.NewDim(width, step+0.5)
+		rec.Fill = svg.NewFill(scale.Color(val))
+		grp.Append(rec.AsElement())
+	}
+	var box svg.Rect
+	box.Dim = svg.NewDim(width, height)
+	box.Fill = svg.NewFill(ColorNone)
+	box.Stroke = svg.NewStroke(ColorBlack, 1)
+	grp.Append(box.AsElement())
+
+	for _, v := range niceNumberTicks(fst, lst, 5) {
+		var (
+			y    = height - height*ratio(v, fst, lst)
+			tick = svg.NewLine(svg.NewPos(width, y), svg.NewPos(width+4, y))
+			txt  = newText(strconv.FormatFloat(v, 'f', -1, 64))
+		)
+		tick.Stroke = svg.NewStroke(ColorBlack, 1)
+		grp.Append(tick.AsElement())
+
+		txt.Font = svg.NewFont(FontSize)
+		txt.Pos = svg.NewPos(width+6, y)
+		txt.Baseline = "middle"
+		grp.Append(txt.AsElement())
+	}
 	return grp.AsElement()
 }
 
+func (c Chart[T, U]) legendColumns(entries []legendEntry, offset float64) int {
+	if c.Legend.Cols > 0 {
+		return c.Legend.Cols
+	}
+	orient := c.Legend.Orient
+	if orient == OrientTop || orient == OrientBottom {
+		var (
+			swatch = FontSize * 2
+			width  float64
+			cols   int
+		)
+		for _, e := range entries {
+			width += swatch + LegendMargin*2 + e.width
+			if width > c.DrawingWidth() && cols > 0 {
+				break
+			}
+			cols++
+		}
+		return cols
+	}
+	for cols := 1; cols < len(entries); cols++ {
+		var height float64
+		for _, col := range splitLegendColumns(entries, cols) {
+			height = math.Max(height, columnHeight(col, offset))
+		}
+		if height <= c.DrawingHeight()-LegendMargin*4 {
+			return cols
+		}
+	}
+	return len(entries)
+}
+
+func (c Chart[T, U]) legendPosition(width, height float64) (float64, float64) {
+	var (
+		orient = c.Legend.Orient
+		margin = LegendMargin * 2
+		left   = c.Padding.Left + (c.DrawingWidth()-width)/2
+		top    = c.Padding.Top + (c.DrawingHeight()-height)/2
+	)
+	if orient == 0 {
+		orient = OrientTop | OrientRight
+	}
+	switch {
+	case orient&OrientLeft != 0:
+		left = c.Padding.Left + margin
+	case orient&OrientRight != 0:
+		left = c.Width - c.Padding.Right - width - margin
+	}
+	switch {
+	case orient&OrientTop != 0:
+		top = c.Padding.Top + margin
+	case orient&OrientBottom != 0:
+		top = c.Height - c.Padding.Bottom - height - margin
+	}
+	return left, top
+}
+
 func (c Chart[T, U]) drawAxis() svg.Element {
 	var g svg.Group
 	g.Id = "axis"
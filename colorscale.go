@@ -0,0 +1,275 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type ColorSpace int
+
+const (
+	InterpolateLab ColorSpace = iota
+	InterpolateHCL
+)
+
+// ColorScale maps a value to a color interpolated between a list of colors.
+// A sequential scale has a domain of two values; a diverging scale has a
+// third value in its domain giving the middle of the scale.
+type ColorScale struct {
+	Colors []string
+	Domain []float64
+	Space  ColorSpace
+}
+
+func SequentialScale(min, max float64, colors ...string) ColorScale {
+	return ColorScale{
+		Colors: colors,
+		Domain: []float64{min, max},
+	}
+}
+
+func DivergingScale(min, mid, max float64, colors ...string) ColorScale {
+	return ColorScale{
+		Colors: colors,
+		Domain: []float64{min, mid, max},
+	}
+}
+
+func (c ColorScale) Min() float64 {
+	if len(c.Domain) == 0 {
+		return 0
+	}
+	return c.Domain[0]
+}
+
+func (c ColorScale) Max() float64 {
+	if len(c.Domain) == 0 {
+		return 1
+	}
+	return c.Domain[len(c.Domain)-1]
+}
+
+func (c ColorScale) Color(v float64) string {
+	switch len(c.Colors) {
+	case 0:
+		return ColorNone
+	case 1:
+		return c.Colors[0]
+	}
+	var (
+		t = c.position(v)
+		n = float64(len(c.Colors) - 1)
+		i = int(math.Floor(t * n))
+	)
+	if i >= len(c.Colors)-1 {
+		i = len(c.Colors) - 2
+	}
+	var (
+		fst, _ = parseColor(c.Colors[i])
+		lst, _ = parseColor(c.Colors[i+1])
+		pos    = t*n - float64(i)
+	)
+	return c.interpolate(fst, lst, pos).String()
+}
+
+// Check reports an error for the first color of the scale that is neither an
+// hexadecimal value nor a SVG color name.
+func (c ColorScale) Check() error {
+	for _, str := range c.Colors {
+		if _, ok := parseColor(str); !ok {
+			return fmt.Errorf("%s: unknown color", str)
+		}
+	}
+	return nil
+}
+
+// position gives the relative position of the value in the domain of the
+// scale between 0 and 1.
+func (c ColorScale) position(v float64) float64 {
+	var t float64
+	switch len(c.Domain) {
+	case 2:
+		t = ratio(v, c.Domain[0], c.Domain[1])
+	case 3:
+		if v < c.Domain[1] {
+			t = ratio(v, c.Domain[0], c.Domain[1]) / 2
+		} else {
+			t = 0.5 + ratio(v, c.Domain[1], c.Domain[2])/2
+		}
+	default:
+		t = v
+	}
+	return math.Max(0, math.Min(1, t))
+}
+
+func (c ColorScale) interpolate(fst, lst rgb, t float64) rgb {
+	var (
+		a = fst.lab()
+		b = lst.lab()
+	)
+	if c.Space == InterpolateHCL {
+		return a.hcl().interpolate(b.hcl(), t).lab().rgb()
+	}
+	return lab{
+		L: a.L + (b.L-a.L)*t,
+		A: a.A + (b.A-a.A)*t,
+		B: a.B + (b.B-a.B)*t,
+	}.rgb()
+}
+
+func ratio(v, fst, lst float64) float64 {
+	if fst == lst {
+		return 0
+	}
+	return (v - fst) / (lst - fst)
+}
+
+type rgb struct {
+	R, G, B float64
+}
+
+// parseColor gives the color of an hexadecimal value or of a SVG color name.
+// Unknown colors are black.
+func parseColor(str string) (rgb, bool) {
+	str = strings.TrimSpace(str)
+	if hex, ok := NamedColor(str); ok {
+		str = hex
+	}
+	str = strings.TrimPrefix(str, "#")
+	if len(str) == 3 {
+		str = string([]byte{str[0], str[0], str[1], str[1], str[2], str[2]})
+	}
+	n, err := strconv.ParseUint(str, 16, 32)
+	if err != nil || len(str) != 6 {
+		return rgb{}, false
+	}
+	return rgb{
+		R: float64(n>>16&0xff) / 255,
+		G: float64(n>>8&0xff) / 255,
+		B: float64(n&0xff) / 255,
+	}, true
+}
+
+func (c rgb) String() string {
+	channel := func(v float64) int {
+		return int(math.Round(math.Max(0, math.Min(1, v)) * 255))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", channel(c.R), channel(c.G), channel(c.B))
+}
+
+// lab converts a sRGB color to the CIE Lab space with a D65 white point.
+func (c rgb) lab() lab {
+	var (
+		r = toLinear(c.R)
+		g = toLinear(c.G)
+		b = toLinear(c.B)
+		x = (0.4124564*r + 0.3575761*g + 0.1804375*b) / whiteX
+		y = (0.2126729*r + 0.7151522*g + 0.0721750*b) / whiteY
+		z = (0.0193339*r + 0.1191920*g + 0.9503041*b) / whiteZ
+	)
+	x, y, z = labForward(x), labForward(y), labForward(z)
+	return lab{
+		L: 116*y - 16,
+		A: 500 * (x - y),
+		B: 200 * (y - z),
+	}
+}
+
+const (
+	whiteX = 0.95047
+	whiteY = 1.0
+	whiteZ = 1.08883
+
+	labEpsilon = 216.0 / 24389
+	labKappa   = 24389.0 / 27
+)
+
+type lab struct {
+	L, A, B float64
+}
+
+func (c lab) rgb() rgb {
+	var (
+		fy = (c.L + 16) / 116
+		fx = fy + c.A/500
+		fz = fy - c.B/200
+		x  = labBackward(fx) * whiteX
+		y  = labBackward(fy) * whiteY
+		z  = labBackward(fz) * whiteZ
+	)
+	return rgb{
+		R: fromLinear(3.2404542*x - 1.5371385*y - 0.4985314*z),
+		G: fromLinear(-0.9692660*x + 1.8760108*y + 0.0415560*z),
+		B: fromLinear(0.0556434*x - 0.2040259*y + 1.0572252*z),
+	}
+}
+
+func (c lab) hcl() hcl {
+	h := math.Atan2(c.B, c.A) * rad2deg
+	if h < 0 {
+		h += fullcircle
+	}
+	return hcl{
+		H: h,
+		C: math.Hypot(c.A, c.B),
+		L: c.L,
+	}
+}
+
+type hcl struct {
+	H, C, L float64
+}
+
+// interpolate follows the shortest path between the two hues.
+func (c hcl) interpolate(other hcl, t float64) hcl {
+	diff := other.H - c.H
+	if diff > halfcircle {
+		diff -= fullcircle
+	} else if diff < -halfcircle {
+		diff += fullcircle
+	}
+	return hcl{
+		H: c.H + diff*t,
+		C: c.C + (other.C-c.C)*t,
+		L: c.L + (other.L-c.L)*t,
+	}
+}
+
+func (c hcl) lab() lab {
+	rad := c.H * deg2rad
+	return lab{
+		L: c.L,
+		A: c.C * math.Cos(rad),
+		B: c.C * math.Sin(rad),
+	}
+}
+
+func labForward(v float64) float64 {
+	if v > labEpsilon {
+		return math.Cbrt(v)
+	}
+	return (labKappa*v + 16) / 116
+}
+
+func labBackward(v float64) float64 {
+	if c := v * v * v; c > labEpsilon {
+		return c
+	}
+	return (116*v - 16) / labKappa
+}
+
+func toLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func fromLinear(v float64) float64 {
+	if v <= 0.0031308 {
+		return 12.92 * v
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
@@ -0,0 +1,64 @@
+package charts
+
+import (
+	"testing"
+)
+
+func TestColorScale(t *testing.T) {
+	tests := []struct {
+		Scale ColorScale
+		Value float64
+		Want  string
+	}{
+		{Scale: SequentialScale(0, 10, "#000000", "#ffffff"), Value: 0, Want: "#000000"},
+		{Scale: SequentialScale(0, 10, "#000000", "#ffffff"), Value: 10, Want: "#ffffff"},
+		{Scale: SequentialScale(0, 10, "#000000", "#ffffff"), Value: 20, Want: "#ffffff"},
+		{Scale: SequentialScale(0, 10, "#000000", "#ffffff"), Value: -5, Want: "#000000"},
+		{Scale: SequentialScale(0, 10, "#ff0000", "#ff0000"), Value: 5, Want: "#ff0000"},
+		{Scale: DivergingScale(-1, 0, 10, "#0000ff", "#ffffff", "#ff0000"), Value: 0, Want: "#ffffff"},
+		{Scale: DivergingScale(-1, 0, 10, "#0000ff", "#ffffff", "#ff0000"), Value: -1, Want: "#0000ff"},
+		{Scale: SequentialScale(0, 1, "white", "steelblue"), Value: 0, Want: "#ffffff"},
+		{Scale: SequentialScale(0, 1, "white", "steelblue"), Value: 1, Want: "#4682b4"},
+		{Scale: DivergingScale(-1, 0, 1, "Blue", "white", "red"), Value: 1, Want: "#ff0000"},
+	}
+	for _, c := range tests {
+		got := c.Scale.Color(c.Value)
+		if got != c.Want {
+			t.Errorf("color mismatched for %f: want %s, got %s", c.Value, c.Want, got)
+		}
+	}
+}
+
+func TestColorScaleMonotonic(t *testing.T) {
+	scale := SequentialScale(0, 1, "#000000", "#ffffff")
+	scale.Space = InterpolateHCL
+	var prev string
+	for i := 0; i <= 10; i++ {
+		got := scale.Color(float64(i) / 10)
+		if prev != "" && got < prev {
+			t.Errorf("color should get lighter: %s after %s", got, prev)
+		}
+		prev = got
+	}
+}
+
+func TestColorScaleCheck(t *testing.T) {
+	tests := []struct {
+		Colors []string
+		Valid  bool
+	}{
+		{Colors: []string{"#000", "#ffffff"}, Valid: true},
+		{Colors: []string{"white", "steelblue"}, Valid: true},
+		{Colors: []string{"white", "steelbleu"}, Valid: false},
+		{Colors: []string{"#12345", "black"}, Valid: false},
+	}
+	for _, c := range tests {
+		err := SequentialScale(0, 1, c.Colors...).Check()
+		if c.Valid && err != nil {
+			t.Errorf("%q: unexpected error: %s", c.Colors, err)
+		}
+		if !c.Valid && err == nil {
+			t.Errorf("%q: expected error", c.Colors)
+		}
+	}
+}
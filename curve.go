@@ -0,0 +1,160 @@
+package charts
+
+import (
+	"math"
+
+	"github.com/midbel/svg"
+)
+
+type curveFunc func(*svg.Path, []svg.Pos)
+
+func cardinalCurve(tension float64) curveFunc {
+	k := (1 - tension) / 6
+	return func(pat *svg.Path, points []svg.Pos) {
+		for i := 1; i < len(points); i++ {
+			var (
+				p0 = points[i-1]
+				p1 = points[i-1]
+				p2 = points[i]
+				p3 = points[i]
+			)
+			if i > 1 {
+				p0 = points[i-2]
+			}
+			if i < len(points)-1 {
+				p3 = points[i+1]
+			}
+			ctrl1 := svg.NewPos(p1.X+(p2.X-p0.X)*k, p1.Y+(p2.Y-p0.Y)*k)
+			ctrl2 := svg.NewPos(p2.X-(p3.X-p1.X)*k, p2.Y-(p3.Y-p1.Y)*k)
+			pat.AbsCubicCurve(p2, ctrl1, ctrl2)
+		}
+	}
+}
+
+// monotoneCurve interpolates points with a cubic Hermite spline whose
+// tangents are chosen so that the curve stays monotone in y between two
+// consecutive points (Steffen's method).
+func monotoneCurve(pat *svg.Path, points []svg.Pos) {
+	if len(points) < 3 {
+		for _, p := range points[1:] {
+			pat.AbsLineTo(p)
+		}
+		return
+	}
+	var (
+		n      = len(points)
+		slopes = make([]float64, n)
+	)
+	for i := 1; i < n-1; i++ {
+		slopes[i] = steffenSlope(points[i-1], points[i], points[i+1])
+	}
+	slopes[0] = endSlope(points[0], points[1], slopes[1])
+	slopes[n-1] = endSlope(points[n-2], points[n-1], slopes[n-2])
+	for i := 1; i < n; i++ {
+		var (
+			p0 = points[i-1]
+			p1 = points[i]
+			dx = (p1.X - p0.X) / 3
+		)
+		ctrl1 := svg.NewPos(p0.X+dx, p0.Y+dx*slopes[i-1])
+		ctrl2 := svg.NewPos(p1.X-dx, p1.Y-dx*slopes[i])
+		pat.AbsCubicCurve(p1, ctrl1, ctrl2)
+	}
+}
+
+func steffenSlope(p0, p1, p2 svg.Pos) float64 {
+	var (
+		h0 = p1.X - p0.X
+		h1 = p2.X - p1.X
+		s0 = secant(p0, p1)
+		s1 = secant(p1, p2)
+	)
+	if h0+h1 == 0 {
+		return 0
+	}
+	p := (s0*h1 + s1*h0) / (h0 + h1)
+	return (sign(s0) + sign(s1)) * math.Min(math.Min(math.Abs(s0), math.Abs(s1)), 0.5*math.Abs(p))
+}
+
+func endSlope(p0, p1 svg.Pos, slope float64) float64 {
+	h := p1.X - p0.X
+	if h == 0 {
+		return slope
+	}
+	return (3*(p1.Y-p0.Y)/h - slope) / 2
+}
+
+func secant(p0, p1 svg.Pos) float64 {
+	h := p1.X - p0.X
+	if h == 0 {
+		return 0
+	}
+	return (p1.Y - p0.Y) / h
+}
+
+// naturalCurve interpolates points with a natural cubic spline: second
+// derivative is zero at both ends of the curve.
+func naturalCurve(pat *svg.Path, points []svg.Pos) {
+	if len(points) < 3 {
+		for _, p := range points[1:] {
+			pat.AbsLineTo(p)
+		}
+		return
+	}
+	var (
+		xs = make([]float64, len(points))
+		ys = make([]float64, len(points))
+	)
+	for i := range points {
+		xs[i] = points[i].X
+		ys[i] = points[i].Y
+	}
+	var (
+		ax, bx = naturalControls(xs)
+		ay, by = naturalControls(ys)
+	)
+	for i := 1; i < len(points); i++ {
+		ctrl1 := svg.NewPos(ax[i-1], ay[i-1])
+		ctrl2 := svg.NewPos(bx[i-1], by[i-1])
+		pat.AbsCubicCurve(points[i], ctrl1, ctrl2)
+	}
+}
+
+func naturalControls(values []float64) ([]float64, []float64) {
+	var (
+		n = len(values) - 1
+		a = make([]float64, n)
+		b = make([]float64, n)
+		r = make([]float64, n)
+	)
+	a[0], b[0], r[0] = 0, 2, values[0]+2*values[1]
+	for i := 1; i < n-1; i++ {
+		a[i], b[i], r[i] = 1, 4, 4*values[i]+2*values[i+1]
+	}
+	a[n-1], b[n-1], r[n-1] = 2, 7, 8*values[n-1]+values[n]
+	for i := 1; i < n; i++ {
+		m := a[i] / b[i-1]
+		b[i] -= m
+		r[i] -= m * r[i-1]
+	}
+	a[n-1] = r[n-1] / b[n-1]
+	for i := n - 2; i >= 0; i-- {
+		a[i] = (r[i] - a[i+1]) / b[i]
+	}
+	b[n-1] = (values[n] + a[n-1]) / 2
+	for i := 0; i < n-1; i++ {
+		b[i] = 2*values[i+1] - a[i+1]
+	}
+	return a, b
+}
+
+func sign(f float64) float64 {
+	switch {
+	case f < 0:
+		return -1
+	case f > 0:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/midbel/buddy/ast"
 	"github.com/midbel/charts"
+	"github.com/midbel/charts/output"
 	"github.com/midbel/svg"
 	"github.com/midbel/svg/layout"
 )
@@ -47,9 +49,38 @@ type Renderer interface {
 	Render(io.Writer)
 }
 
+const (
+	AnnotateHLine = "hline"
+	AnnotateVLine = "vline"
+	AnnotateHSpan = "hspan"
+	AnnotateVSpan = "vspan"
+	AnnotatePoint = "point"
+)
+
+// Annotation marks a value (hline, vline), a range of values (hspan, vspan)
+// or a point of the chart. Values are kept as given and parsed according to
+// the type of the x and y data of the chart.
+type Annotation struct {
+	Type   string
+	Values []string
+	Text   string
+	Style
+}
+
+func DefaultAnnotationStyle() Style {
+	style := charts.DefaultStyle()
+	style.LineColor = "firebrick"
+	style.LineType = charts.StyleDashed
+	style.FillList = []string{"firebrick"}
+	style.FillOpacity = 0.15
+	style.FontColor = "firebrick"
+	return style
+}
+
 type Legend struct {
 	Title    string
 	Position []string
+	Cols     int
 }
 
 type Cell struct {
@@ -68,6 +99,7 @@ func MakeCell(c Config) Cell {
 	}
 	empty.Config.Cells = nil
 	empty.Config.Elements = nil
+	empty.Config.Annotations = nil
 	return empty
 }
 
@@ -75,8 +107,10 @@ type Config struct {
 	Title string
 	Legend
 
-	Path     string
-	Elements []Element
+	Path        string
+	Format      string
+	Elements    []Element
+	Annotations []Annotation
 
 	Width  float64
 	Height float64
@@ -89,6 +123,7 @@ type Config struct {
 
 	X     Input
 	Y     Input
+	Y2    Input
 	Cells []Cell
 
 	Env     *Environ[any]
@@ -107,15 +142,21 @@ func Default() Config {
 	}
 	cfg.X.Type = TypeNumber
 	cfg.Y.Type = TypeNumber
+	cfg.Y2.Type = TypeNumber
 
 	return cfg
 }
 
 func (c Config) Render() error {
+	var (
+		rdr layout.Renderer
+		err error
+	)
 	if len(c.Cells) > 0 {
-		return c.renderDashboard()
+		rdr, err = c.renderDashboard()
+	} else {
+		rdr, err = c.render()
 	}
-	rdr, err := c.render()
 	if err != nil {
 		return err
 	}
@@ -128,8 +169,14 @@ func (c Config) Render() error {
 		defer f.Close()
 		w = f
 	}
-	rdr.Render(w)
-	return nil
+	return output.Write(w, rdr.Element(), c.getFormat())
+}
+
+func (c Config) getFormat() string {
+	if c.Format != "" {
+		return c.Format
+	}
+	return output.FormatFromPath(c.Path)
 }
 
 func (c Config) render() (Renderer, error) {
@@ -137,6 +184,7 @@ func (c Config) render() (Renderer, error) {
 		err   error
 		maker Renderer
 	)
+	c.Elements = c.withLabels()
 	switch {
 	case c.X.isNumber() && c.Y.isNumber():
 		maker, err = c.numberChart()
@@ -144,13 +192,46 @@ func (c Config) render() (Renderer, error) {
 		maker, err = c.timeChart()
 	case c.X.isString() && c.Y.isNumber():
 		maker, err = c.categoryChart()
+	case c.X.isString() && c.Y.isString():
+		maker, err = c.gridChart()
+	case c.X.isTime() && c.Y.isString():
+		maker, err = c.timeGridChart()
+	case c.X.isNumber() && c.Y.isString():
+		maker, err = c.horizontalChart()
 	default:
 		err = fmt.Errorf("unsupported chart type %s/%s", c.X.Type, c.Y.Type)
 	}
 	return maker, err
 }
 
-func (c Config) renderDashboard() error {
+// withLabels gives to the styles of the elements the functions formatting the
+// labels of their values.
+func (c Config) withLabels() []Element {
+	list := make([]Element, len(c.Elements))
+	for i, e := range c.Elements {
+		switch st := e.Style.(type) {
+		case NumberStyle:
+			if st.LabelFormat != "" {
+				st.Labels.Format = numberFormat(c, st.LabelFormat)
+			}
+			e.Style = st
+		case CategoryStyle:
+			if st.LabelFormat != "" {
+				st.Labels.Format = numberFormat(c, st.LabelFormat)
+			}
+			e.Style = st
+		case CircularStyle:
+			if st.LabelFormat != "" {
+				st.Labels.Format = numberFormat(c, st.LabelFormat)
+			}
+			e.Style = st
+		}
+		list[i] = e
+	}
+	return list
+}
+
+func (c Config) renderDashboard() (layout.Renderer, error) {
 	var (
 		err  error
 		grid layout.Grid
@@ -166,6 +247,7 @@ func (c Config) renderDashboard() error {
 	}
 
 	for _, cs := range c.Cells {
+		cs.Config.Format = c.getFormat()
 		cell := layout.Cell{
 			X: cs.Row,
 			Y: cs.Col,
@@ -173,18 +255,11 @@ func (c Config) renderDashboard() error {
 			H: cs.Height,
 		}
 		if cell.Item, err = cs.Config.render(); err != nil {
-			return err
+			return nil, err
 		}
 		grid.Cells = append(grid.Cells, cell)
 	}
-
-	w, err := os.Create(c.Path)
-	if err != nil {
-		return err
-	}
-	defer w.Close()
-
-	return grid.Render(w)
+	return grid, nil
 }
 
 func (c Config) computeGridDimension() (int, int) {
@@ -203,25 +278,21 @@ func (c Config) computeGridDimension() (int, int) {
 }
 
 func (c Config) categoryChart() (Renderer, error) {
-	var (
-		xrange = c.createRangeX()
-		yrange = c.createRangeY()
-		chart  = createChart[string, float64](c)
-		series = make([]charts.Data, len(c.Elements))
-	)
-	xscale, err := c.X.CategoryScale(xrange)
+	chart := createChart[string, float64](c)
+	list, err := loadSeries(c, Element.categorySerie, func(in Input, rg charts.Range) (StringScale, error) {
+		return in.CategoryScale(rg)
+	})
 	if err != nil {
 		return nil, err
 	}
-	yscale, err := c.Y.NumberScale(yrange, true)
+	list = overlayPolar(list)
+	xscale, err := c.X.withValues(xValues(list)).CategoryScale(c.createRangeX())
 	if err != nil {
 		return nil, err
 	}
-	for i := range c.Elements {
-		series[i], err = c.Elements[i].CategorySerie(xscale, yscale)
-		if err != nil {
-			return nil, err
-		}
+	yscale, y2scale, err := numberScales(c, list)
+	if err != nil {
+		return nil, err
 	}
 	switch c.X.Position {
 	case PosBottom:
@@ -241,30 +312,168 @@ func (c Config) categoryChart() (Renderer, error) {
 	if err != nil {
 		return nil, err
 	}
-	return chartRenderer(chart, series), nil
+	if err := setSecondAxis(c, &chart, y2scale); err != nil {
+		return nil, err
+	}
+	if chart.Annotations, err = createAnnotations(c.Annotations, parseString, parseNumber); err != nil {
+		return nil, err
+	}
+	return chartRenderer(chart, scaleDualSeries(c, list, xscale, yscale, y2scale)), nil
 }
 
-func (c Config) timeChart() (Renderer, error) {
+func (c Config) horizontalChart() (Renderer, error) {
+	var (
+		chart = createChart[float64, string](c)
+		list  = make([]HorizontalSerie, len(c.Elements))
+		err   error
+	)
+	for i := range c.Elements {
+		if list[i], err = c.Elements[i].HorizontalSerie(); err != nil {
+			return nil, err
+		}
+	}
+	xscale, err := c.X.withValues(xValues(list)).NumberScale(c.createRangeX(), false)
+	if err != nil {
+		return nil, err
+	}
+	yscale, err := c.Y.withValues(yValues(list)).CategoryScale(c.createRangeY())
+	if err != nil {
+		return nil, err
+	}
+	switch c.X.Position {
+	case PosBottom:
+		chart.Bottom, err = c.X.GetNumberAxis(c, xscale)
+	case PosTop:
+		chart.Top, err = c.X.GetNumberAxis(c, xscale)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch c.Y.Position {
+	case PosLeft:
+		chart.Left, err = c.Y.GetCategoryAxis(c, yscale)
+	case PosRight:
+		chart.Right, err = c.Y.GetCategoryAxis(c, yscale)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if chart.Annotations, err = createAnnotations(c.Annotations, parseNumber, parseString); err != nil {
+		return nil, err
+	}
+	return chartRenderer(chart, scaleSeries(list, xscale, yscale)), nil
+}
+
+func (c Config) gridChart() (Renderer, error) {
 	var (
-		xrange = c.createRangeX()
-		yrange = c.createRangeY()
-		chart  = createChart[time.Time, float64](c)
-		series = make([]charts.Data, len(c.Elements))
+		chart = createChart[string, string](c)
+		list  = make([]GridSerie, len(c.Elements))
+		err   error
 	)
-	xscale, err := c.X.TimeScale(xrange, TimeFormat, false)
+	for i := range c.Elements {
+		if list[i], err = c.Elements[i].GridSerie(); err != nil {
+			return nil, err
+		}
+	}
+	xscale, err := c.X.withValues(xValues(list)).CategoryScale(c.createRangeX())
+	if err != nil {
+		return nil, err
+	}
+	yscale, err := c.Y.withValues(yValues(list)).CategoryScale(c.createRangeY())
+	if err != nil {
+		return nil, err
+	}
+	switch c.X.Position {
+	case PosBottom:
+		chart.Bottom, err = c.X.GetCategoryAxis(c, xscale)
+	case PosTop:
+		chart.Top, err = c.X.GetCategoryAxis(c, xscale)
+	}
 	if err != nil {
 		return nil, err
 	}
-	yscale, err := c.Y.NumberScale(yrange, true)
+	switch c.Y.Position {
+	case PosLeft:
+		chart.Left, err = c.Y.GetCategoryAxis(c, yscale)
+	case PosRight:
+		chart.Right, err = c.Y.GetCategoryAxis(c, yscale)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if chart.Annotations, err = createAnnotations(c.Annotations, parseString, parseString); err != nil {
+		return nil, err
+	}
+	return chartRenderer(chart, scaleSeries(list, xscale, yscale)), nil
+}
+
+func (c Config) timeGridChart() (Renderer, error) {
+	var (
+		chart = createChart[time.Time, string](c)
+		list  = make([]TimeGridSerie, len(c.Elements))
+		err   error
+	)
 	for i := range c.Elements {
-		series[i], err = c.Elements[i].TimeSerie(c.TimeFormat, xscale, yscale)
-		if err != nil {
+		if list[i], err = c.Elements[i].TimeGridSerie(c.TimeFormat); err != nil {
 			return nil, err
 		}
 	}
+	xscale, err := c.X.withValues(xValues(list)).TimeScale(c.createRangeX(), TimeFormat, false)
+	if err != nil {
+		return nil, err
+	}
+	yscale, err := c.Y.withValues(yValues(list)).CategoryScale(c.createRangeY())
+	if err != nil {
+		return nil, err
+	}
+	switch c.X.Position {
+	case PosBottom:
+		chart.Bottom, err = c.X.GetTimeAxis(c, xscale)
+	case PosTop:
+		chart.Top, err = c.X.GetTimeAxis(c, xscale)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch c.Y.Position {
+	case PosLeft:
+		chart.Left, err = c.Y.GetCategoryAxis(c, yscale)
+	case PosRight:
+		chart.Right, err = c.Y.GetCategoryAxis(c, yscale)
+	}
+	if err != nil {
+		return nil, err
+	}
+	parseTime, err := makeParseTime(c.TimeFormat)
+	if err != nil {
+		return nil, err
+	}
+	if chart.Annotations, err = createAnnotations(c.Annotations, parseTime, parseString); err != nil {
+		return nil, err
+	}
+	return chartRenderer(chart, scaleSeries(list, xscale, yscale)), nil
+}
+
+func (c Config) timeChart() (Renderer, error) {
+	chart := createChart[time.Time, float64](c)
+	load := func(e Element, x TimeScale) (TimeSerie, error) {
+		return e.timeSerie(c.TimeFormat, x)
+	}
+	list, err := loadSeries(c, load, func(in Input, rg charts.Range) (TimeScale, error) {
+		return in.TimeScale(rg, TimeFormat, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	list = stackAreas(c, list)
+	xscale, err := c.X.withValues(xValues(list)).TimeScale(c.createRangeX(), TimeFormat, false)
+	if err != nil {
+		return nil, err
+	}
+	yscale, y2scale, err := numberScales(c, list)
+	if err != nil {
+		return nil, err
+	}
 	switch c.X.Position {
 	case PosBottom:
 		chart.Bottom, err = c.X.GetTimeAxis(c, xscale)
@@ -283,29 +492,36 @@ func (c Config) timeChart() (Renderer, error) {
 	if err != nil {
 		return nil, err
 	}
-	return chartRenderer(chart, series), nil
+	if err := setSecondAxis(c, &chart, y2scale); err != nil {
+		return nil, err
+	}
+	parseTime, err := makeParseTime(c.TimeFormat)
+	if err != nil {
+		return nil, err
+	}
+	if chart.Annotations, err = createAnnotations(c.Annotations, parseTime, parseNumber); err != nil {
+		return nil, err
+	}
+	return chartRenderer(chart, scaleDualSeries(c, list, xscale, yscale, y2scale)), nil
 }
 
 func (c Config) numberChart() (Renderer, error) {
-	var (
-		xrange = c.createRangeX()
-		yrange = c.createRangeY()
-		chart  = createChart[float64, float64](c)
-		series = make([]charts.Data, len(c.Elements))
-	)
-	xscale, err := c.X.NumberScale(xrange, false)
+	chart := createChart[float64, float64](c)
+	list, err := loadSeries(c, Element.numberSerie, func(in Input, rg charts.Range) (FloatScale, error) {
+		in.Scale = ScaleType{}
+		return in.NumberScale(rg, false)
+	})
 	if err != nil {
 		return nil, err
 	}
-	yscale, err := c.Y.NumberScale(yrange, true)
+	list = stackAreas(c, list)
+	xscale, err := c.X.withValues(xValues(list)).NumberScale(c.createRangeX(), false)
 	if err != nil {
 		return nil, err
 	}
-	for i := range c.Elements {
-		series[i], err = c.Elements[i].NumberSerie(xscale, yscale)
-		if err != nil {
-			return nil, err
-		}
+	yscale, y2scale, err := numberScales(c, list)
+	if err != nil {
+		return nil, err
 	}
 	switch c.X.Position {
 	case PosBottom:
@@ -325,7 +541,45 @@ func (c Config) numberChart() (Renderer, error) {
 	if err != nil {
 		return nil, err
 	}
-	return chartRenderer(chart, series), nil
+	if err := setSecondAxis(c, &chart, y2scale); err != nil {
+		return nil, err
+	}
+	if chart.Annotations, err = createAnnotations(c.Annotations, parseNumber, parseNumber); err != nil {
+		return nil, err
+	}
+	return chartRenderer(chart, scaleDualSeries(c, list, xscale, yscale, y2scale)), nil
+}
+
+// loadSeries loads the series of the elements. The expressions are sampled
+// last, over the domain of x given by the other series when it is not set.
+func loadSeries[T charts.ScalerConstraint](c Config, load func(Element, charts.Scaler[T]) (charts.Serie[T, float64], error), scale func(Input, charts.Range) (charts.Scaler[T], error)) ([]charts.Serie[T, float64], error) {
+	var (
+		list  = make([]charts.Serie[T, float64], len(c.Elements))
+		exprs []int
+		err   error
+	)
+	for i, e := range c.Elements {
+		if e.isExpr() {
+			exprs = append(exprs, i)
+			continue
+		}
+		if list[i], err = load(e, nil); err != nil {
+			return nil, err
+		}
+	}
+	if len(exprs) == 0 {
+		return list, nil
+	}
+	x, err := scale(c.X.withValues(xValues(list)), c.createRangeX())
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range exprs {
+		if list[i], err = load(c.Elements[i], x); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
 }
 
 func (c Config) createRangeX() charts.Range {
@@ -368,7 +622,9 @@ func createChart[T, U charts.ScalerConstraint](cfg Config) charts.Chart[T, U] {
 	} else {
 		ch.Theme = cfg.Theme
 	}
+	ch.Interactive = cfg.getFormat() == output.FormatHTML
 	ch.Legend.Title = cfg.Legend.Title
+	ch.Legend.Cols = cfg.Legend.Cols
 	for _, p := range cfg.Legend.Position {
 		switch p {
 		case "top":
@@ -386,6 +642,201 @@ func createChart[T, U charts.ScalerConstraint](cfg Config) charts.Chart[T, U] {
 	return ch
 }
 
+func createAnnotations[T, U charts.ScalerConstraint](list []Annotation, parseX func(string) (T, error), parseY func(string) (U, error)) ([]charts.Annotation[T, U], error) {
+	var notes []charts.Annotation[T, U]
+	for _, a := range list {
+		var (
+			note = charts.Annotation[T, U]{
+				Text:  a.Text,
+				Style: a.Style,
+			}
+			count = 1
+			err   error
+		)
+		switch a.Type {
+		case AnnotateHLine:
+			note.Type = charts.AnnotateHLine
+		case AnnotateVLine:
+			note.Type = charts.AnnotateVLine
+		case AnnotateHSpan:
+			note.Type = charts.AnnotateHSpan
+			count = 2
+		case AnnotateVSpan:
+			note.Type = charts.AnnotateVSpan
+			count = 2
+		case AnnotatePoint:
+			note.Type = charts.AnnotatePoint
+			count = 2
+		default:
+			return nil, fmt.Errorf("%s: unsupported annotation", a.Type)
+		}
+		if len(a.Values) != count {
+			return nil, fmt.Errorf("%s: expected %d value(s) for annotation, got %d", a.Type, count, len(a.Values))
+		}
+		switch note.Type {
+		case charts.AnnotateHLine:
+			note.Y, err = parseY(a.Values[0])
+		case charts.AnnotateVLine:
+			note.X, err = parseX(a.Values[0])
+		case charts.AnnotateHSpan:
+			if note.Y, err = parseY(a.Values[0]); err == nil {
+				note.Y2, err = parseY(a.Values[1])
+			}
+		case charts.AnnotateVSpan:
+			if note.X, err = parseX(a.Values[0]); err == nil {
+				note.X2, err = parseX(a.Values[1])
+			}
+		case charts.AnnotatePoint:
+			if note.X, err = parseX(a.Values[0]); err == nil {
+				note.Y, err = parseY(a.Values[1])
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", a.Type, err)
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+func parseNumber(str string) (float64, error) {
+	return strconv.ParseFloat(str, 64)
+}
+
+func parseString(str string) (string, error) {
+	return str, nil
+}
+
+// overlayPolar keeps the grid of the first polar serie only: the following
+// ones are drawn over it. Each serie starts its colors after the layers of the
+// previous ones to keep them distinct.
+func overlayPolar(list []CategorySerie) []CategorySerie {
+	var (
+		grid   bool
+		offset int
+	)
+	for i := range list {
+		rdr, ok := list[i].Renderer.(charts.PolarRenderer[string, float64])
+		if !ok {
+			continue
+		}
+		if grid {
+			rdr.Ticks = 0
+		}
+		grid = grid || rdr.Ticks > 0
+		rdr.Offset = offset
+		list[i].Renderer = rdr
+		offset += polarDepth(list[i])
+	}
+	return list
+}
+
+// polarDepth gives the number of layers drawn for a polar serie.
+func polarDepth(serie CategorySerie) int {
+	depth := 1
+	for _, pt := range serie.Points {
+		if n := len(pt.Sub); n > depth {
+			depth = n
+		}
+	}
+	return depth
+}
+
+// stackAreas stacks the areas of the series drawn against the same y axis,
+// the ones of each axis being stacked apart.
+func stackAreas[T charts.ScalerConstraint](c Config, list []charts.Serie[T, float64]) []charts.Serie[T, float64] {
+	var left, right []charts.Serie[T, float64]
+	for i := range list {
+		if c.Elements[i].onRight() {
+			right = append(right, list[i])
+		} else {
+			left = append(left, list[i])
+		}
+	}
+	left = charts.StackAreas(left)
+	right = charts.StackAreas(right)
+	for i := range list {
+		if c.Elements[i].onRight() {
+			list[i], right = right[0], right[1:]
+		} else {
+			list[i], left = left[0], left[1:]
+		}
+	}
+	return list
+}
+
+// numberScales creates the scales of the y axes from the values of the series
+// drawn against them. The scale of the second y axis is nil when no element
+// is drawn against it.
+func numberScales[T charts.ScalerConstraint](c Config, list []charts.Serie[T, float64]) (charts.Scaler[float64], charts.Scaler[float64], error) {
+	var left, right []charts.Serie[T, float64]
+	for i := range list {
+		if c.Elements[i].onRight() {
+			right = append(right, list[i])
+		} else {
+			left = append(left, list[i])
+		}
+	}
+	if len(left) == 0 {
+		left = right
+	}
+	yscale, err := c.Y.withValues(yValues(left)).NumberScale(c.createRangeY(), true)
+	if err != nil || len(right) == 0 {
+		return yscale, nil, err
+	}
+	y2scale, err := c.Y2.withValues(yValues(right)).NumberScale(c.createRangeY(), true)
+	return yscale, y2scale, err
+}
+
+// setSecondAxis sets the axis of the second y scale on the right of the chart
+// or on its left when asked. The side should not be the one of the y axis.
+func setSecondAxis[T charts.ScalerConstraint](c Config, chart *charts.Chart[T, float64], y2scale charts.Scaler[float64]) error {
+	if y2scale == nil {
+		return nil
+	}
+	pos := PosRight
+	if c.Y2.Position == PosLeft {
+		pos = PosLeft
+	}
+	if pos == c.Y.Position {
+		return fmt.Errorf("%s: y2 axis can not be drawn on the side of the y axis", pos)
+	}
+	axis, err := c.Y2.GetNumberAxis(c, y2scale)
+	if err != nil {
+		return err
+	}
+	if pos == PosLeft {
+		chart.Left = axis
+	} else {
+		chart.Right = axis
+	}
+	return nil
+}
+
+func scaleDualSeries[T charts.ScalerConstraint](c Config, list []charts.Serie[T, float64], x charts.Scaler[T], y, y2 charts.Scaler[float64]) []charts.Data {
+	series := scaleSeries(list, x, y)
+	if y2 == nil {
+		return series
+	}
+	for i := range list {
+		if c.Elements[i].onRight() {
+			list[i].Y = y2
+			series[i] = list[i]
+		}
+	}
+	return series
+}
+
+func scaleSeries[T, U charts.ScalerConstraint](list []charts.Serie[T, U], x charts.Scaler[T], y charts.Scaler[U]) []charts.Data {
+	series := make([]charts.Data, len(list))
+	for i := range list {
+		list[i].X = x
+		list[i].Y = y
+		series[i] = list[i]
+	}
+	return series
+}
+
 type chartMaker struct {
 	charts.Drawner
 	series []charts.Data
@@ -1,11 +1,11 @@
 package dash
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"os/exec"
@@ -15,6 +15,8 @@ import (
 	"time"
 
 	"github.com/midbel/buddy/ast"
+	"github.com/midbel/buddy/eval"
+	"github.com/midbel/buddy/types"
 	"github.com/midbel/charts"
 	"github.com/midbel/query"
 	"github.com/midbel/shlex"
@@ -29,31 +31,221 @@ type Element struct {
 	Style any // one of NumberStyle, CategoryStyle, CircularStyle
 }
 
-func (e Element) TimeSerie(timefmt string, x TimeScale, y FloatScale) (charts.Data, error) {
-	ser, err := e.resetSource().TimeSerie(timefmt, x, y)
+// onRight reports whether the element is drawn against the second y axis.
+func (e Element) onRight() bool {
+	switch st := e.Style.(type) {
+	case NumberStyle:
+		return st.Axis == PosRight
+	case CategoryStyle:
+		return st.Axis == PosRight
+	default:
+		return false
+	}
+}
+
+func (e Element) TimeSerie(timefmt string) (TimeSerie, error) {
+	return e.timeSerie(timefmt, nil)
+}
+
+func (e Element) timeSerie(timefmt string, x TimeScale) (TimeSerie, error) {
+	ser, err := e.resetSource().TimeSerie(timefmt, x, nil)
 	if err != nil {
-		return nil, err
+		return ser, err
 	}
 	ser.Renderer, err = getRenderer[time.Time, float64](e.Type, e.Style)
-	return ser, err
+	return markerSerie(ser), err
+}
+
+func (e Element) NumberSerie() (NumberSerie, error) {
+	return e.numberSerie(nil)
 }
 
-func (e Element) NumberSerie(x FloatScale, y FloatScale) (charts.Data, error) {
-	ser, err := e.resetSource().NumberSerie(x, y)
+func (e Element) numberSerie(x FloatScale) (NumberSerie, error) {
+	ser, err := e.resetSource().NumberSerie(x, nil)
 	if err != nil {
-		return nil, err
+		return ser, err
 	}
 	ser.Renderer, err = getRenderer[float64, float64](e.Type, e.Style)
-	return ser, err
+	return markerSerie(ser), err
+}
+
+// markerSerie moves the values selected after the y value of the points of a
+// serie drawn with markers to the size (bubble only) and to the value giving
+// the color of the points. Markers get a color only when all the points have
+// a value for it. When error bars are drawn, the two values following the y
+// value are kept as the bounds of the points.
+func markerSerie[T charts.ScalerConstraint](ser charts.Serie[T, float64]) charts.Serie[T, float64] {
+	rdr, ok := ser.Renderer.(charts.PointRenderer[T, float64])
+	if !ok {
+		return ser
+	}
+	var (
+		points  = make([]charts.Point[T, float64], len(ser.Points))
+		min     = math.Inf(1)
+		max     = math.Inf(-1)
+		colored = len(ser.Points) > 0
+	)
+	for i, pt := range ser.Points {
+		var (
+			extra []float64
+			first = 1
+		)
+		if rdr.Error.Visible && len(pt.Sub) >= 3 {
+			first = 3
+		}
+		for j := first; j < len(pt.Sub); j++ {
+			extra = append(extra, pt.Sub[j].Y)
+		}
+		if rdr.MaxSize > 0 && len(extra) > 0 {
+			pt.Size, extra = extra[0], extra[1:]
+		}
+		if len(extra) > 0 {
+			pt.Value = extra[0]
+			min = math.Min(min, pt.Value)
+			max = math.Max(max, pt.Value)
+		} else {
+			colored = false
+		}
+		if first > 1 {
+			pt.Sub = pt.Sub[:first]
+		} else {
+			pt.Sub = nil
+		}
+		points[i] = pt
+	}
+	ser.Points = points
+	if colored {
+		rdr.Colors = charts.SequentialScale(min, max, rdr.Colors.Colors...)
+	} else {
+		rdr.Colors = charts.ColorScale{}
+	}
+	ser.Renderer = rdr
+	return ser
 }
 
-func (e Element) CategorySerie(x StringScale, y FloatScale) (charts.Data, error) {
-	ser, err := e.resetSource().CategorySerie(x, y)
+func (e Element) CategorySerie() (CategorySerie, error) {
+	return e.categorySerie(nil)
+}
+
+func (e Element) categorySerie(x StringScale) (CategorySerie, error) {
+	ser, err := e.resetSource().CategorySerie(x, nil)
 	if err != nil {
-		return nil, err
+		return ser, err
 	}
 	ser.Renderer, err = getCategoryRenderer[string, float64](e.Type, e.Style)
-	return ser, err
+	return errorSerie(ser, e.Style), err
+}
+
+// errorSerie gives back to the points of a serie drawn with error bars their
+// first value instead of the sum of the selected values.
+func errorSerie(ser CategorySerie, style any) CategorySerie {
+	st, ok := style.(CategoryStyle)
+	if !ok || !st.Error.Visible {
+		return ser
+	}
+	for i := range ser.Points {
+		if len(ser.Points[i].Sub) == 3 {
+			ser.Points[i].Y = ser.Points[i].Sub[0].Y
+		}
+	}
+	return ser
+}
+
+// GridSerie loads the values of a matrix: each selected column of a row gives
+// a cell whose Y is the name of the column.
+func (e Element) GridSerie() (GridSerie, error) {
+	var grid GridSerie
+	ser, err := e.resetSource().CategorySerie(nil, nil)
+	if err != nil {
+		return grid, err
+	}
+	grid.Title = ser.Title
+	var (
+		min = math.Inf(1)
+		max = math.Inf(-1)
+	)
+	for _, pt := range ser.Points {
+		cells := pt.Sub
+		if len(cells) == 0 {
+			cells = append(cells, charts.CategoryPoint(ser.Title, pt.Y))
+		}
+		for _, c := range cells {
+			cell := charts.Point[string, string]{
+				X:     pt.X,
+				Y:     c.X,
+				Value: c.Y,
+			}
+			grid.Points = append(grid.Points, cell)
+			min = math.Min(min, c.Y)
+			max = math.Max(max, c.Y)
+		}
+	}
+	if len(grid.Points) == 0 {
+		min, max = 0, 1
+	}
+	grid.Renderer, err = getHeatmapRenderer[string, string](e.Type, e.Style, min, max)
+	return grid, err
+}
+
+// TimeGridSerie loads the values of a matrix whose rows are given by a time.
+func (e Element) TimeGridSerie(timefmt string) (TimeGridSerie, error) {
+	var tg TimeGridSerie
+	grid, err := e.GridSerie()
+	if err != nil {
+		return tg, err
+	}
+	parseTime, err := makeParseTime(timefmt)
+	if err != nil {
+		return tg, err
+	}
+	tg.Title = grid.Title
+	for _, pt := range grid.Points {
+		when, err := parseTime(pt.X)
+		if err != nil {
+			return tg, err
+		}
+		cell := charts.Point[time.Time, string]{
+			X:     when,
+			Y:     pt.Y,
+			Value: pt.Value,
+		}
+		tg.Points = append(tg.Points, cell)
+	}
+	rdr := grid.Renderer.(charts.HeatmapRenderer[string, string])
+	tg.Renderer = charts.HeatmapRenderer[time.Time, string]{
+		Style: rdr.Style,
+		Scale: rdr.Scale,
+	}
+	return tg, nil
+}
+
+// HorizontalSerie loads a category serie and swaps the axes of its points to
+// have the categories on the y axis.
+func (e Element) HorizontalSerie() (HorizontalSerie, error) {
+	var hs HorizontalSerie
+	ser, err := e.resetSource().CategorySerie(nil, nil)
+	if err != nil {
+		return hs, err
+	}
+	ser = errorSerie(ser, e.Style)
+	hs.Title = ser.Title
+	for _, pt := range ser.Points {
+		hs.Points = append(hs.Points, pt.Reverse())
+	}
+	hs.Renderer, err = getHorizontalRenderer[float64, string](e.Type, e.Style)
+	return hs, err
+}
+
+func (e Element) isExpr() bool {
+	switch d := e.Data.(type) {
+	case Expr:
+		return true
+	case Pipeline:
+		e.Data = d.Source
+		return e.isExpr()
+	default:
+		return false
+	}
 }
 
 func (e Element) resetSource() DataSource {
@@ -67,15 +259,22 @@ func (e Element) resetSource() DataSource {
 	case LocalFile:
 		d.Using = e.Using
 		return d
+	case Pipeline:
+		e.Data = d.Source
+		d.Source = e.resetSource()
+		return d
 	default:
 		return e.Data
 	}
 }
 
 type (
-	TimeSerie     = charts.Serie[time.Time, float64]
-	NumberSerie   = charts.Serie[float64, float64]
-	CategorySerie = charts.Serie[string, float64]
+	TimeSerie       = charts.Serie[time.Time, float64]
+	NumberSerie     = charts.Serie[float64, float64]
+	CategorySerie   = charts.Serie[string, float64]
+	GridSerie       = charts.Serie[string, string]
+	TimeGridSerie   = charts.Serie[time.Time, string]
+	HorizontalSerie = charts.Serie[float64, string]
 
 	TimeScale   = charts.Scaler[time.Time]
 	FloatScale  = charts.Scaler[float64]
@@ -98,17 +297,46 @@ func (i Limit) zero() bool {
 }
 
 type Using struct {
-	X int
-	Y Selector
+	X     int
+	XName string
+	Y     Selector
+}
+
+func defaultUsing() Using {
+	return Using{
+		X: 0,
+		Y: SelectSingle(1),
+	}
 }
 
 func (u Using) valid() bool {
 	return u.Y != nil
 }
 
+// resolve gives the indexes of the columns selected by their name in the
+// header.
+func (u Using) resolve(header []string) (Using, error) {
+	if u.XName != "" {
+		x, err := columnIndex(header, u.XName)
+		if err != nil {
+			return u, err
+		}
+		u.X = x
+	}
+	if r, ok := u.Y.(resolver); ok {
+		y, err := r.resolve(header)
+		if err != nil {
+			return u, err
+		}
+		u.Y = y
+	}
+	return u, nil
+}
+
 type Exec struct {
 	Ident   string
 	Command string
+	Dialect
 }
 
 func (e Exec) TimeSerie(timefmt string, x TimeScale, y FloatScale) (ser TimeSerie, err error) {
@@ -116,11 +344,11 @@ func (e Exec) TimeSerie(timefmt string, x TimeScale, y FloatScale) (ser TimeSeri
 	if err != nil {
 		return
 	}
-	get, err := getTimeFunc(0, SelectSingle(1), timefmt)
+	get, err := getTimeFunc(timefmt)
 	if err != nil {
 		return
 	}
-	points, err := readPoints(strings.NewReader(out), get)
+	points, err := readPoints(strings.NewReader(out), e.Dialect, defaultUsing(), get)
 	if err != nil {
 		return
 	}
@@ -136,8 +364,7 @@ func (e Exec) NumberSerie(x FloatScale, y FloatScale) (ser NumberSerie, err erro
 		return
 	}
 
-	get := getNumberFunc(0, SelectSingle(1))
-	points, err := readPoints(strings.NewReader(out), get)
+	points, err := readPoints(strings.NewReader(out), e.Dialect, defaultUsing(), getNumberFunc)
 	if err != nil {
 		return
 	}
@@ -153,8 +380,7 @@ func (e Exec) CategorySerie(x StringScale, y FloatScale) (ser CategorySerie, err
 		return
 	}
 
-	get := getCategoryFunc(0, SelectSingle(1))
-	points, err := readPoints(strings.NewReader(out), get)
+	points, err := readPoints(strings.NewReader(out), e.Dialect, defaultUsing(), getCategoryFunc)
 	if err != nil {
 		return
 	}
@@ -177,21 +403,127 @@ func (e Exec) execute() (string, error) {
 	return string(out), nil
 }
 
+const DefaultSamples = 100
+
+// Expr samples an expression over the domain of x. The value of each point is
+// the result of the expression with x bound to the sampled value. For time,
+// x is given in seconds since the beginning of the domain and t in seconds
+// since the epoch. The expression is evaluated once per category otherwise.
 type Expr struct {
-	Ident string
-	Expr  ast.Expression
+	Ident   string
+	Expr    ast.Expression
+	Samples int
 }
 
 func (e Expr) TimeSerie(timefmt string, x TimeScale, y FloatScale) (ser TimeSerie, err error) {
-	return
+	if x == nil {
+		return ser, errDomain
+	}
+	var (
+		bounds   = x.Values(1)
+		fst, lst = slices.Fst(bounds), slices.Lst(bounds)
+		step     = lst.Sub(fst) / time.Duration(e.samples()-1)
+		points   []charts.Point[time.Time, float64]
+	)
+	for i := 0; i < e.samples(); i++ {
+		var (
+			when = fst.Add(step * time.Duration(i))
+			env  = types.EmptyEnv()
+		)
+		if i == e.samples()-1 {
+			when = lst
+		}
+		defineValue(env, "x", when.Sub(fst).Seconds())
+		defineValue(env, "t", float64(when.Unix()))
+		val, err := e.evaluate(env)
+		if err != nil {
+			return ser, err
+		}
+		points = append(points, charts.Point[time.Time, float64]{X: when, Y: val})
+	}
+	ser = createSerie[time.Time, float64](e.Ident, points)
+	ser.X = x
+	ser.Y = y
+	return ser, nil
 }
 
 func (e Expr) NumberSerie(x FloatScale, y FloatScale) (ser NumberSerie, err error) {
-	return
+	if x == nil {
+		return ser, errDomain
+	}
+	var (
+		bounds   = x.Values(1)
+		fst, lst = slices.Fst(bounds), slices.Lst(bounds)
+		step     = (lst - fst) / float64(e.samples()-1)
+		points   []charts.Point[float64, float64]
+	)
+	for i := 0; i < e.samples(); i++ {
+		var (
+			at  = fst + step*float64(i)
+			env = types.EmptyEnv()
+		)
+		defineValue(env, "x", at)
+		val, err := e.evaluate(env)
+		if err != nil {
+			return ser, err
+		}
+		points = append(points, charts.Point[float64, float64]{X: at, Y: val})
+	}
+	ser = createSerie[float64, float64](e.Ident, points)
+	ser.X = x
+	ser.Y = y
+	return ser, nil
 }
 
 func (e Expr) CategorySerie(x StringScale, y FloatScale) (ser CategorySerie, err error) {
-	return
+	if x == nil {
+		return ser, errDomain
+	}
+	var points []charts.Point[string, float64]
+	for _, str := range x.Values(0) {
+		env := types.EmptyEnv()
+		defineValue(env, "x", str)
+		val, err := e.evaluate(env)
+		if err != nil {
+			return ser, err
+		}
+		points = append(points, charts.Point[string, float64]{X: str, Y: val})
+	}
+	ser = createSerie[string, float64](e.Ident, points)
+	ser.X = x
+	ser.Y = y
+	return ser, nil
+}
+
+func (e Expr) samples() int {
+	if e.Samples < 2 {
+		return DefaultSamples
+	}
+	return e.Samples
+}
+
+// evaluate gives the result of the expression as a number. Results that are
+// not numbers give a missing value.
+func (e Expr) evaluate(env *types.Environ) (float64, error) {
+	res, err := eval.Execute(e.Expr, env)
+	if err != nil {
+		return 0, err
+	}
+	if res == nil {
+		return math.NaN(), nil
+	}
+	val, err := strconv.ParseFloat(res.String(), 64)
+	if err != nil {
+		return math.NaN(), nil
+	}
+	return val, nil
+}
+
+func defineValue(env *types.Environ, ident string, value any) {
+	p, err := types.CreatePrimitive(value)
+	if err == nil {
+		env.Define(ident, p)
+	}
 }
 
 type HttpFile struct {
@@ -200,6 +532,7 @@ type HttpFile struct {
 	Query string
 	Using
 	Limit
+	Dialect
 
 	Method string
 	Body   string
@@ -232,11 +565,11 @@ func (f HttpFile) TimeSerie(timefmt string, x TimeScale, y FloatScale) (ser Time
 		return ser, fmt.Errorf("invalid column selector given")
 	}
 
-	get, err := getTimeFunc(f.X, f.Y, timefmt)
+	get, err := getTimeFunc(timefmt)
 	if err != nil {
 		return
 	}
-	points, err := readPoints(r, get)
+	points, err := readPoints(r, f.Dialect, f.Using, get)
 	if err != nil {
 		return
 	}
@@ -269,8 +602,7 @@ func (f HttpFile) NumberSerie(x FloatScale, y FloatScale) (ser NumberSerie, err
 		return ser, fmt.Errorf("invalid column selector given")
 	}
 
-	get := getNumberFunc(f.X, f.Y)
-	points, err := readPoints(r, get)
+	points, err := readPoints(r, f.Dialect, f.Using, getNumberFunc)
 	if err != nil {
 		return
 	}
@@ -303,8 +635,7 @@ func (f HttpFile) CategorySerie(x StringScale, y FloatScale) (ser CategorySerie,
 		return ser, fmt.Errorf("invalid column selector given")
 	}
 
-	get := getCategoryFunc(f.X, f.Y)
-	points, err := readPoints(r, get)
+	points, err := readPoints(r, f.Dialect, f.Using, getCategoryFunc)
 	if err != nil {
 		return
 	}
@@ -342,14 +673,15 @@ func (f HttpFile) execute() (io.ReadCloser, bool, error) {
 type LocalData struct {
 	Ident   string
 	Content string
+	Dialect
 }
 
 func (d LocalData) TimeSerie(timefmt string, x TimeScale, y FloatScale) (ser TimeSerie, err error) {
-	get, err := getTimeFunc(0, SelectSingle(1), timefmt)
+	get, err := getTimeFunc(timefmt)
 	if err != nil {
 		return
 	}
-	points, err := readPoints(strings.NewReader(d.Content), get)
+	points, err := readPoints(strings.NewReader(d.Content), d.Dialect, defaultUsing(), get)
 	if err != nil {
 		return
 	}
@@ -360,8 +692,7 @@ func (d LocalData) TimeSerie(timefmt string, x TimeScale, y FloatScale) (ser Tim
 }
 
 func (d LocalData) NumberSerie(x FloatScale, y FloatScale) (ser NumberSerie, err error) {
-	get := getNumberFunc(0, SelectSingle(1))
-	points, err := readPoints(strings.NewReader(d.Content), get)
+	points, err := readPoints(strings.NewReader(d.Content), d.Dialect, defaultUsing(), getNumberFunc)
 	if err != nil {
 		return
 	}
@@ -372,8 +703,7 @@ func (d LocalData) NumberSerie(x FloatScale, y FloatScale) (ser NumberSerie, err
 }
 
 func (d LocalData) CategorySerie(x StringScale, y FloatScale) (ser CategorySerie, err error) {
-	get := getCategoryFunc(0, SelectSingle(1))
-	points, err := readPoints(strings.NewReader(d.Content), get)
+	points, err := readPoints(strings.NewReader(d.Content), d.Dialect, defaultUsing(), getCategoryFunc)
 	if err != nil {
 		return
 	}
@@ -389,6 +719,7 @@ type LocalFile struct {
 	Query string
 	Using
 	Limit
+	Dialect
 }
 
 func (f LocalFile) Name() string {
@@ -416,11 +747,11 @@ func (f LocalFile) TimeSerie(timefmt string, x TimeScale, y FloatScale) (ser Tim
 	}
 	defer r.Close()
 
-	get, err := getTimeFunc(f.X, f.Y, timefmt)
+	get, err := getTimeFunc(timefmt)
 	if err != nil {
 		return
 	}
-	points, err := readPoints(r, get)
+	points, err := readPoints(r, f.Dialect, f.Using, get)
 	if err != nil {
 		return
 	}
@@ -450,8 +781,7 @@ func (f LocalFile) NumberSerie(x FloatScale, y FloatScale) (ser NumberSerie, err
 	}
 	defer r.Close()
 
-	get := getNumberFunc(f.X, f.Y)
-	points, err := readPoints(r, get)
+	points, err := readPoints(r, f.Dialect, f.Using, getNumberFunc)
 	if err != nil {
 		return
 	}
@@ -481,8 +811,7 @@ func (f LocalFile) CategorySerie(x StringScale, y FloatScale) (ser CategorySerie
 	}
 	defer r.Close()
 
-	get := getCategoryFunc(f.X, f.Y)
-	points, err := readPoints(r, get)
+	points, err := readPoints(r, f.Dialect, f.Using, getCategoryFunc)
 	if err != nil {
 		return
 	}
@@ -511,14 +840,25 @@ func splitPoints[T, U charts.ScalerConstraint](lim Limit, points []charts.Point[
 	return points
 }
 
-type getFunc[T, U charts.ScalerConstraint] func([]string) (charts.Point[T, U], error)
+type getFunc[T, U charts.ScalerConstraint] func(row, header []string) (charts.Point[T, U], error)
+
+type makeFunc[T, U charts.ScalerConstraint] func(Using) getFunc[T, U]
 
-func readPoints[T, U charts.ScalerConstraint](r io.Reader, get getFunc[T, U]) ([]charts.Point[T, U], error) {
+// readPoints reads the points from the rows of a csv file. The header, when
+// there is one, gives the columns selected by their name.
+func readPoints[T, U charts.ScalerConstraint](r io.Reader, dialect Dialect, use Using, create makeFunc[T, U]) ([]charts.Point[T, U], error) {
+	rs, header, err := dialect.reader(r)
+	if err != nil {
+		return nil, err
+	}
+	use, err = use.resolve(header)
+	if err != nil {
+		return nil, err
+	}
 	var (
-		rs   = csv.NewReader(r)
+		get  = create(use)
 		list []charts.Point[T, U]
 	)
-	rs.Read()
 	for {
 		row, err := rs.Read()
 		if err != nil {
@@ -527,7 +867,7 @@ func readPoints[T, U charts.ScalerConstraint](r io.Reader, get getFunc[T, U]) ([
 			}
 			return nil, err
 		}
-		pt, err := get(row)
+		pt, err := get(row, header)
 		if err != nil {
 			return nil, err
 		}
@@ -536,8 +876,12 @@ func readPoints[T, U charts.ScalerConstraint](r io.Reader, get getFunc[T, U]) ([
 	return list, nil
 }
 
-func getCategoryFunc(x int, y Selector) getFunc[string, float64] {
-	get := func(row []string) (charts.Point[string, float64], error) {
+func getCategoryFunc(use Using) getFunc[string, float64] {
+	var (
+		x = use.X
+		y = use.Y
+	)
+	get := func(row, header []string) (charts.Point[string, float64], error) {
 		var (
 			pt  charts.Point[string, float64]
 			err error
@@ -550,9 +894,16 @@ func getCategoryFunc(x int, y Selector) getFunc[string, float64] {
 		if len(values) == 1 {
 			pt.Y = slices.Fst(values)
 		} else {
-			var total float64
+			var (
+				total float64
+				cols  = y.columns()
+			)
 			for i := range values {
-				s := charts.CategoryPoint(fmt.Sprintf("%d", i), values[i])
+				label := fmt.Sprintf("%d", i)
+				if len(cols) == len(values) && cols[i] < len(header) {
+					label = header[cols[i]]
+				}
+				s := charts.CategoryPoint(label, values[i])
 				pt.Sub = append(pt.Sub, s)
 				total += values[i]
 			}
@@ -563,31 +914,42 @@ func getCategoryFunc(x int, y Selector) getFunc[string, float64] {
 	return get
 }
 
-func getTimeFunc(x int, y Selector, timefmt string) (getFunc[time.Time, float64], error) {
+func getTimeFunc(timefmt string) (makeFunc[time.Time, float64], error) {
 	parseTime, err := makeParseTime(timefmt)
 	if err != nil {
 		return nil, err
 	}
-	get := func(row []string) (charts.Point[time.Time, float64], error) {
-		var (
-			pt  charts.Point[time.Time, float64]
-			err error
-		)
-		if pt.X, err = parseTime(row[x]); err != nil {
-			return pt, err
-		}
-		values, err := y.Select(row)
-		if err != nil {
-			return pt, err
+	create := func(use Using) getFunc[time.Time, float64] {
+		return func(row, _ []string) (charts.Point[time.Time, float64], error) {
+			var (
+				pt  charts.Point[time.Time, float64]
+				err error
+			)
+			if pt.X, err = parseTime(row[use.X]); err != nil {
+				return pt, err
+			}
+			values, err := use.Y.Select(row)
+			if err != nil {
+				return pt, err
+			}
+			pt.Y = slices.Fst(values)
+			if len(values) > 1 {
+				for i := range values {
+					pt.Sub = append(pt.Sub, charts.TimePoint(pt.X, values[i]))
+				}
+			}
+			return pt, nil
 		}
-		pt.Y = slices.Fst(values)
-		return pt, nil
 	}
-	return get, nil
+	return create, nil
 }
 
-func getNumberFunc(x int, y Selector) getFunc[float64, float64] {
-	get := func(row []string) (charts.Point[float64, float64], error) {
+func getNumberFunc(use Using) getFunc[float64, float64] {
+	var (
+		x = use.X
+		y = use.Y
+	)
+	get := func(row, _ []string) (charts.Point[float64, float64], error) {
 		var (
 			pt  charts.Point[float64, float64]
 			err error
@@ -600,6 +962,11 @@ func getNumberFunc(x int, y Selector) getFunc[float64, float64] {
 			return pt, err
 		}
 		pt.Y = slices.Fst(values)
+		if len(values) > 1 {
+			for i := range values {
+				pt.Sub = append(pt.Sub, charts.NumberPoint(pt.X, values[i]))
+			}
+		}
 		return pt, nil
 	}
 	return get
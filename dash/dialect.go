@@ -0,0 +1,218 @@
+package dash
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type HeaderMode int
+
+const (
+	HeaderPresent HeaderMode = iota
+	HeaderMissing
+	HeaderAuto
+)
+
+const DelimBlank = " "
+
+// Dialect describes how the rows of a csv file are read. The delimiter is
+// guessed from the first lines when it is not set. The first row is the header
+// unless Header says otherwise (or guesses it). A blank delimiter splits the
+// rows on runs of blanks.
+type Dialect struct {
+	Delimiter  string
+	Header     HeaderMode
+	Comment    string
+	LazyQuotes bool
+	Skip       int
+}
+
+func GetHeaderMode(str string) (HeaderMode, error) {
+	switch str {
+	case "auto":
+		return HeaderAuto, nil
+	default:
+		ok, err := strconv.ParseBool(str)
+		if err != nil {
+			return HeaderPresent, fmt.Errorf("%s: invalid header (expected true, false or auto)", str)
+		}
+		if !ok {
+			return HeaderMissing, nil
+		}
+		return HeaderPresent, nil
+	}
+}
+
+func GetDelimiter(str string) string {
+	switch str {
+	case "tab", `\t`:
+		return "\t"
+	case "semicolon":
+		return ";"
+	case "comma":
+		return ","
+	case "pipe":
+		return "|"
+	case "space", "blank", "whitespace":
+		return DelimBlank
+	default:
+		return str
+	}
+}
+
+type rowReader interface {
+	Read() ([]string, error)
+}
+
+// reader gives the reader of the rows of the file and its header if any.
+func (d Dialect) reader(r io.Reader) (rowReader, []string, error) {
+	lines, err := d.lines(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	delim := d.Delimiter
+	if delim == "" {
+		delim = sniffDelimiter(lines)
+	}
+	var rs rowReader
+	if delim == DelimBlank {
+		rs = &blankReader{lines: lines}
+	} else {
+		cr := csv.NewReader(strings.NewReader(strings.Join(lines, "\n")))
+		cr.Comma, _ = utf8.DecodeRuneInString(delim)
+		cr.LazyQuotes = d.LazyQuotes
+		rs = cr
+	}
+
+	first, err := rs.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		return rs, nil, err
+	}
+	switch d.Header {
+	case HeaderMissing:
+		return &pushbackReader{rows: [][]string{first}, rowReader: rs}, nil, nil
+	case HeaderAuto:
+	default:
+		return rs, first, nil
+	}
+	second, err := rs.Read()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+	if hasHeader(first, second) {
+		return &pushbackReader{rows: [][]string{second}, rowReader: rs}, first, nil
+	}
+	return &pushbackReader{rows: [][]string{first, second}, rowReader: rs}, nil, nil
+}
+
+// lines gives the lines of the file without the skipped and commented lines.
+func (d Dialect) lines(r io.Reader) ([]string, error) {
+	var (
+		scan  = bufio.NewScanner(r)
+		lines []string
+	)
+	for i := 0; scan.Scan(); i++ {
+		line := scan.Text()
+		if i < d.Skip {
+			continue
+		}
+		if d.Comment != "" && strings.HasPrefix(strings.TrimSpace(line), d.Comment) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scan.Err()
+}
+
+// sniffDelimiter gives the candidate delimiter found the most in the first
+// line. Lines without any of them are split on blanks if they have some.
+func sniffDelimiter(lines []string) string {
+	var line string
+	for _, str := range lines {
+		if strings.TrimSpace(str) != "" {
+			line = str
+			break
+		}
+	}
+	var (
+		delim = DefaultDelim
+		count int
+	)
+	for _, str := range []string{",", "\t", ";", "|"} {
+		if n := strings.Count(line, str); n > count {
+			delim, count = str, n
+		}
+	}
+	if count == 0 && len(strings.Fields(line)) > 1 {
+		delim = DelimBlank
+	}
+	return delim
+}
+
+// hasHeader reports whether the first row is a header: one of its fields is
+// not a number while the same field of the second row is. Rows without any
+// number are considered to start with a header.
+func hasHeader(first, second []string) bool {
+	if len(second) == 0 {
+		return true
+	}
+	var numbers int
+	for i := range first {
+		if i >= len(second) {
+			break
+		}
+		fst, snd := isNumber(first[i]), isNumber(second[i])
+		if !fst && snd {
+			return true
+		}
+		if fst && snd {
+			numbers++
+		}
+	}
+	return numbers == 0
+}
+
+func isNumber(str string) bool {
+	_, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+	return err == nil
+}
+
+type blankReader struct {
+	lines []string
+}
+
+func (r *blankReader) Read() ([]string, error) {
+	for len(r.lines) > 0 {
+		fields := strings.Fields(r.lines[0])
+		r.lines = r.lines[1:]
+		if len(fields) > 0 {
+			return fields, nil
+		}
+	}
+	return nil, io.EOF
+}
+
+type pushbackReader struct {
+	rows [][]string
+	rowReader
+}
+
+func (r *pushbackReader) Read() ([]string, error) {
+	for len(r.rows) > 0 {
+		row := r.rows[0]
+		r.rows = r.rows[1:]
+		if row != nil {
+			return row, nil
+		}
+	}
+	return r.rowReader.Read()
+}
@@ -0,0 +1,159 @@
+package dash
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSniffDelimiter(t *testing.T) {
+	data := []struct {
+		Lines []string
+		Want  string
+	}{
+		{
+			Lines: []string{"date,open,close"},
+			Want:  ",",
+		},
+		{
+			Lines: []string{"", "date;open;close"},
+			Want:  ";",
+		},
+		{
+			Lines: []string{"date\topen,close\tvolume"},
+			Want:  "\t",
+		},
+		{
+			Lines: []string{"a|b|c"},
+			Want:  "|",
+		},
+		{
+			Lines: []string{"1   2  3"},
+			Want:  DelimBlank,
+		},
+		{
+			Lines: []string{"single"},
+			Want:  DefaultDelim,
+		},
+	}
+	for _, d := range data {
+		got := sniffDelimiter(d.Lines)
+		if got != d.Want {
+			t.Errorf("%q: delimiter mismatched: want %q, got %q", d.Lines, d.Want, got)
+		}
+	}
+}
+
+func TestHasHeader(t *testing.T) {
+	data := []struct {
+		First  []string
+		Second []string
+		Want   bool
+	}{
+		{
+			First:  []string{"date", "close"},
+			Second: []string{"2022-01-03", "145.07"},
+			Want:   true,
+		},
+		{
+			First:  []string{"1", "2"},
+			Second: []string{"3", "4"},
+			Want:   false,
+		},
+		{
+			First:  []string{"a", "2"},
+			Second: []string{"b", "4"},
+			Want:   false,
+		},
+		{
+			First:  []string{"name", "team"},
+			Second: []string{"john", "blue"},
+			Want:   true,
+		},
+		{
+			First: []string{"name", "2019"},
+			Want:  true,
+		},
+	}
+	for _, d := range data {
+		got := hasHeader(d.First, d.Second)
+		if got != d.Want {
+			t.Errorf("%q/%q: header mismatched: want %t, got %t", d.First, d.Second, d.Want, got)
+		}
+	}
+}
+
+func TestDialectReader(t *testing.T) {
+	data := []struct {
+		Dialect
+		Input  string
+		Header []string
+		Rows   [][]string
+	}{
+		{
+			Input:  "name,2019,2020\nA,5,6\nB,7,8\n",
+			Header: []string{"name", "2019", "2020"},
+			Rows:   [][]string{{"A", "5", "6"}, {"B", "7", "8"}},
+		},
+		{
+			Dialect: Dialect{Header: HeaderMissing},
+			Input:   "1;2\n3;4\n",
+			Rows:    [][]string{{"1", "2"}, {"3", "4"}},
+		},
+		{
+			Dialect: Dialect{Header: HeaderAuto},
+			Input:   "1\t2\n3\t4\n",
+			Rows:    [][]string{{"1", "2"}, {"3", "4"}},
+		},
+		{
+			Dialect: Dialect{Header: HeaderAuto},
+			Input:   "x,y\n1,2\n",
+			Header:  []string{"x", "y"},
+			Rows:    [][]string{{"1", "2"}},
+		},
+		{
+			Dialect: Dialect{Skip: 2, Comment: "#"},
+			Input:   "generated\nby hand\nx,y\n# comment\n1,2\n  # indented\n3,4\n",
+			Header:  []string{"x", "y"},
+			Rows:    [][]string{{"1", "2"}, {"3", "4"}},
+		},
+		{
+			Dialect: Dialect{Delimiter: DelimBlank},
+			Input:   "x   y\n1  2\n\n3 \t 4\n",
+			Header:  []string{"x", "y"},
+			Rows:    [][]string{{"1", "2"}, {"3", "4"}},
+		},
+		{
+			Dialect: Dialect{LazyQuotes: true},
+			Input:   "x,y\na \"b\" c,1\n",
+			Header:  []string{"x", "y"},
+			Rows:    [][]string{{"a \"b\" c", "1"}},
+		},
+	}
+	for _, d := range data {
+		rs, header, err := d.reader(strings.NewReader(d.Input))
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", d.Input, err)
+			continue
+		}
+		if !reflect.DeepEqual(header, d.Header) {
+			t.Errorf("%q: header mismatched: want %q, got %q", d.Input, d.Header, header)
+		}
+		var rows [][]string
+		for {
+			row, err := rs.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%q: unexpected error: %s", d.Input, err)
+			}
+			rows = append(rows, row)
+		}
+		if !reflect.DeepEqual(rows, d.Rows) {
+			t.Errorf("%q: rows mismatched: want %q, got %q", d.Input, d.Rows, rows)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package dash
 
 import (
-	"errors"
 	"fmt"
 	"time"
 
@@ -11,10 +10,9 @@ import (
 	"github.com/midbel/charts"
 )
 
-var errScaler = errors.New("scale not set")
-
 type Input struct {
 	Type   string
+	Scale  ScaleType
 	Scaler ScalerMaker
 	Domain
 }
@@ -31,25 +29,52 @@ func (i Input) isString() bool {
 	return i.Type == TypeString
 }
 
+func (i Input) isAuto() bool {
+	if i.Scaler == nil {
+		return true
+	}
+	_, ok := i.Scaler.(autoScaler)
+	return ok
+}
+
+// withValues gives the values of the loaded series to the scaler when the
+// domain of the input has to be computed from the data.
+func (i Input) withValues(values any) Input {
+	if !i.isAuto() {
+		return i
+	}
+	auto, _ := i.Scaler.(autoScaler)
+	i.Scaler = auto.withValues(values)
+	return i
+}
+
 func (i Input) CategoryScale(rg charts.Range) (charts.Scaler[string], error) {
 	if i.Scaler == nil {
-		return nil, errScaler
+		i.Scaler = autoScaler{}
 	}
 	return i.Scaler.CategoryScale(rg)
 }
 
 func (i Input) NumberScale(rg charts.Range, reverse bool) (charts.Scaler[float64], error) {
 	if i.Scaler == nil {
-		return nil, errScaler
+		i.Scaler = autoScaler{}
 	}
-	return i.Scaler.NumberScale(rg, reverse)
+	scale, err := i.Scaler.NumberScale(rg, i.Scale, reverse)
+	if err == nil && i.Nice {
+		scale = charts.NiceScaler(scale, i.Ticks)
+	}
+	return scale, err
 }
 
 func (i Input) TimeScale(rg charts.Range, format string, reverse bool) (charts.Scaler[time.Time], error) {
 	if i.Scaler == nil {
-		return nil, errScaler
+		i.Scaler = autoScaler{}
+	}
+	scale, err := i.Scaler.TimeScale(rg, format, reverse)
+	if err == nil && i.Nice {
+		scale = charts.NiceScaler(scale, i.Ticks)
 	}
-	return i.Scaler.TimeScale(rg, format, reverse)
+	return scale, err
 }
 
 type Domain struct {
@@ -61,6 +86,7 @@ type Domain struct {
 	OuterTicks bool
 	LabelTicks bool
 	BandTicks  bool
+	Nice       bool
 }
 
 func (d Domain) GetCategoryAxis(cfg Config, scale charts.Scaler[string]) (charts.Axis[string], error) {
@@ -80,20 +106,20 @@ func (d Domain) GetCategoryAxis(cfg Config, scale charts.Scaler[string]) (charts
 }
 
 func (d Domain) GetNumberAxis(cfg Config, scale charts.Scaler[float64]) (charts.Axis[float64], error) {
-	var (
-		axe    = createAxis[float64](d, scale)
-		format func(float64) string
-	)
+	axe := createAxis[float64](d, scale)
+	axe.Format = numberFormat(cfg, d.Format)
+	return axe, nil
+}
 
-	if expr, err := cfg.Scripts.Resolve(d.Format); err == nil {
-		format = wrapExpr[float64](expr)
-	} else {
-		format = func(f float64) string {
-			return fmt.Sprintf(d.Format, f)
-		}
+// numberFormat gives the function formatting numbers with the script defined
+// under the given name or with the format used as a verb of fmt.
+func numberFormat(cfg Config, format string) func(float64) string {
+	if expr, err := cfg.Scripts.Resolve(format); err == nil {
+		return wrapExpr[float64](expr)
+	}
+	return func(f float64) string {
+		return fmt.Sprintf(format, f)
 	}
-	axe.Format = format
-	return axe, nil
 }
 
 func (d Domain) GetTimeAxis(cfg Config, scale charts.Scaler[time.Time]) (charts.Axis[time.Time], error) {
@@ -115,6 +141,7 @@ func createAxis[T charts.ScalerConstraint](d Domain, scale charts.Scaler[T]) cha
 		WithOuterTicks: d.OuterTicks,
 		WithLabelTicks: d.LabelTicks,
 		WithBands:      d.BandTicks,
+		WithNiceTicks:  d.Nice,
 	}
 }
 
@@ -1,12 +1,8 @@
 package dash
 
 import (
-	"encoding/csv"
 	"errors"
-	"fmt"
-	"io"
 	"math"
-	"os"
 	"strconv"
 	"time"
 
@@ -16,11 +12,44 @@ import (
 
 type ScalerMaker interface {
 	TimeScale(charts.Range, string, bool) (charts.Scaler[time.Time], error)
-	NumberScale(charts.Range, bool) (charts.Scaler[float64], error)
+	NumberScale(charts.Range, ScaleType, bool) (charts.Scaler[float64], error)
 	CategoryScale(charts.Range) (charts.Scaler[string], error)
 }
 
-var errValues = errors.New("not enough values given for domain")
+const (
+	ScaleLinear = "linear"
+	ScaleLog    = "log"
+	ScalePow    = "pow"
+	ScaleSqrt   = "sqrt"
+	ScaleSymlog = "symlog"
+)
+
+type ScaleType struct {
+	Kind  string
+	Param float64
+}
+
+func (s ScaleType) numberScaler(fst, lst float64, rg charts.Range) charts.Scaler[float64] {
+	dom := charts.NumberDomain(fst, lst)
+	switch s.Kind {
+	case ScaleLog:
+		return charts.LogScaler(dom, s.Param, rg)
+	case ScalePow:
+		return charts.PowScaler(dom, s.Param, rg)
+	case ScaleSqrt:
+		return charts.SqrtScaler(dom, rg)
+	case ScaleSymlog:
+		return charts.SymlogScaler(dom, s.Param, rg)
+	default:
+		return charts.NumberScaler(dom, rg)
+	}
+}
+
+var (
+	errValues = errors.New("not enough values given for domain")
+	errDomain = errors.New("domain of x required to sample expression")
+	errLog    = errors.New("domain of log scale should be above zero")
+)
 
 type listScaler struct {
 	values []string
@@ -54,7 +83,7 @@ func (s listScaler) TimeScale(rg charts.Range, format string, reverse bool) (cha
 	return charts.TimeScaler(charts.TimeDomain(fst, lst), rg), nil
 }
 
-func (s listScaler) NumberScale(rg charts.Range, reverse bool) (charts.Scaler[float64], error) {
+func (s listScaler) NumberScale(rg charts.Range, kind ScaleType, reverse bool) (charts.Scaler[float64], error) {
 	if len(s.values) < 2 {
 		return nil, errValues
 	}
@@ -66,133 +95,253 @@ func (s listScaler) NumberScale(rg charts.Range, reverse bool) (charts.Scaler[fl
 	if err != nil {
 		return nil, err
 	}
+	if kind.Kind == ScaleLog && (fst <= 0 || lst <= 0) {
+		return nil, errLog
+	}
 	if reverse {
 		fst, lst = lst, fst
 	}
-	return charts.NumberScaler(charts.NumberDomain(fst, lst), rg), nil
+	return kind.numberScaler(fst, lst, rg), nil
 }
 
 func (s listScaler) CategoryScale(rg charts.Range) (charts.Scaler[string], error) {
 	return charts.StringScaler(s.values, rg), nil
 }
 
-type fileScaler struct {
-	path string
-	Indexer
+type autoScaler struct {
+	margin float64
+	zero   bool
+
+	numbers []float64
+	times   []time.Time
+	strings []string
 }
 
-func ScaleFromFile(path string, ix Indexer) ScalerMaker {
-	return fileScaler{
-		path:    path,
-		Indexer: ix,
+func ScaleAuto(margin float64, zero bool) ScalerMaker {
+	return autoScaler{
+		margin: margin,
+		zero:   zero,
 	}
 }
 
-func (s fileScaler) TimeScale(rg charts.Range, format string, reverse bool) (charts.Scaler[time.Time], error) {
-	parseTime, err := makeParseTime(format)
-	if err != nil {
-		return nil, err
-	}
-	cols := s.columns()
-	if len(cols) != 1 {
-		return nil, fmt.Errorf("invalid number of column given")
+func (s autoScaler) TimeScale(rg charts.Range, format string, reverse bool) (charts.Scaler[time.Time], error) {
+	if len(s.times) == 0 {
+		return nil, errValues
 	}
 	var (
-		fd time.Time
-		td time.Time
-		ix = slices.Fst(cols)
+		fst = slices.Fst(s.times)
+		lst = slices.Lst(s.times)
 	)
-	err = s.readFile(func(row []string) error {
-		if ix < 0 || ix >= len(row) {
-			return ErrIndex
-		}
-		when, err := parseTime(row[ix])
-		if err != nil {
-			return err
-		}
-		if fd.IsZero() || when.Before(fd) {
-			fd = when
+	if fst.After(lst) {
+		fst, lst = lst, fst
+	}
+	for _, t := range slices.Rest(s.times) {
+		if t.Before(fst) {
+			fst = t
 		}
-		if td.IsZero() || when.After(td) {
-			td = when
+		if t.After(lst) {
+			lst = t
 		}
-		return nil
-	})
-	return charts.TimeScaler(charts.TimeDomain(fd, td), rg), err
+	}
+	if s.margin > 0 {
+		diff := time.Duration(float64(lst.Sub(fst)) * s.margin)
+		fst = fst.Add(-diff)
+		lst = lst.Add(diff)
+	}
+	if reverse {
+		fst, lst = lst, fst
+	}
+	return charts.TimeScaler(charts.TimeDomain(fst, lst), rg), nil
 }
 
-func (s fileScaler) NumberScale(rg charts.Range, reverse bool) (charts.Scaler[float64], error) {
-	sel, ok := s.Indexer.(Selector)
-	if !ok {
-		return nil, fmt.Errorf("invalid selection string")
-	}
+func (s autoScaler) NumberScale(rg charts.Range, kind ScaleType, reverse bool) (charts.Scaler[float64], error) {
 	var (
-		min float64
-		max float64
+		min  = math.Inf(1)
+		max  = math.Inf(-1)
+		skip bool
 	)
-	err := s.readFile(func(row []string) error {
-		vs, err := sel.Select(row)
-		if err != nil {
-			return err
+	for _, f := range s.numbers {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
 		}
-		if len(vs) != 1 {
-			return fmt.Errorf("too many values retrieved from selector")
+		// values below or equal to zero can not be drawn with a log scale
+		if kind.Kind == ScaleLog && f <= 0 {
+			skip = true
+			continue
 		}
-		min = math.Min(min, slices.Fst(vs))
-		max = math.Max(max, slices.Fst(vs))
-		return nil
-	})
+		min = math.Min(min, f)
+		max = math.Max(max, f)
+	}
+	if math.IsInf(min, 0) || math.IsInf(max, 0) {
+		if skip {
+			return nil, errLog
+		}
+		return nil, errValues
+	}
+	if kind.Kind != ScaleLog {
+		if s.zero {
+			min = math.Min(min, 0)
+			max = math.Max(max, 0)
+		}
+		if s.margin > 0 {
+			diff := (max - min) * s.margin
+			if !s.zero || min != 0 {
+				min -= diff
+			}
+			if !s.zero || max != 0 {
+				max += diff
+			}
+		}
+	}
+	if min == max {
+		if kind.Kind == ScaleLog {
+			min, max = min/10, max*10
+		} else {
+			min, max = min-1, max+1
+		}
+	}
 	if reverse {
 		min, max = max, min
 	}
-	return charts.NumberScaler(charts.NumberDomain(min, max), rg), err
+	return kind.numberScaler(min, max, rg), nil
 }
 
-func (s fileScaler) CategoryScale(rg charts.Range) (charts.Scaler[string], error) {
-	cols := s.columns()
-	if len(cols) != 1 {
-		return nil, fmt.Errorf("invalid number of column given")
-	}
+func (s autoScaler) CategoryScale(rg charts.Range) (charts.Scaler[string], error) {
 	var (
 		seen  = make(map[string]struct{})
 		empty = struct{}{}
 		list  []string
-		ix    = slices.Fst(cols)
 	)
-	err := s.readFile(func(row []string) error {
-		if ix < 0 || ix >= len(row) {
-			return fmt.Errorf("invalid index")
+	for _, str := range s.strings {
+		if _, ok := seen[str]; ok {
+			continue
 		}
-		_, ok := seen[row[ix]]
-		if !ok {
-			list = append(list, row[ix])
-			seen[row[ix]] = empty
+		seen[str] = empty
+		list = append(list, str)
+	}
+	if len(list) == 0 {
+		return nil, errValues
+	}
+	return charts.StringScaler(list, rg), nil
+}
+
+func (s autoScaler) withValues(values any) autoScaler {
+	switch vs := values.(type) {
+	case []float64:
+		s.numbers = vs
+	case []time.Time:
+		s.times = vs
+	case []string:
+		s.strings = vs
+	}
+	return s
+}
+
+type binner[T, U charts.ScalerConstraint] interface {
+	Bins(charts.Serie[T, U]) []charts.Bin
+}
+
+// binValues gives the edges and the values of the bins computed by the
+// renderer of the serie if it distributes its points into bins.
+func binValues[T, U charts.ScalerConstraint](serie charts.Serie[T, U]) ([]T, []U, bool) {
+	b, ok := serie.Renderer.(binner[T, U])
+	if !ok {
+		return nil, nil, ok
+	}
+	var (
+		xs []T
+		ys []U
+	)
+	for _, b := range b.Bins(serie) {
+		for _, v := range []float64{b.Min, b.Max} {
+			if x, ok := any(v).(T); ok {
+				xs = append(xs, x)
+			}
+		}
+		for _, v := range []float64{0, b.Value} {
+			if y, ok := any(v).(U); ok {
+				ys = append(ys, y)
+			}
 		}
+	}
+	return xs, ys, true
+}
+
+type summarizer[T, U charts.ScalerConstraint] interface {
+	Summaries(charts.Serie[T, U]) []charts.Summary
+}
+
+// summaryValues gives the extent of the summaries computed by the renderer
+// of the serie if it summarizes the distribution of its values.
+func summaryValues[T, U charts.ScalerConstraint](serie charts.Serie[T, U]) ([]U, bool) {
+	s, ok := serie.Renderer.(summarizer[T, U])
+	if !ok {
+		return nil, ok
+	}
+	var list []U
+	for _, s := range s.Summaries(serie) {
+		if len(s.Values) == 0 {
+			continue
+		}
+		for _, v := range []float64{slices.Fst(s.Values), slices.Lst(s.Values)} {
+			if y, ok := any(v).(U); ok {
+				list = append(list, y)
+			}
+		}
+	}
+	return list, true
+}
+
+type stacker[T, U charts.ScalerConstraint] interface {
+	Baselines(charts.Serie[T, U]) []U
+}
+
+// baseValues gives the baselines of the points of a serie when its renderer
+// stacks it on top of other series.
+func baseValues[T, U charts.ScalerConstraint](serie charts.Serie[T, U]) []U {
+	s, ok := serie.Renderer.(stacker[T, U])
+	if !ok {
 		return nil
-	})
-	return charts.StringScaler(list, rg), err
+	}
+	return s.Baselines(serie)
 }
 
-func (s fileScaler) readFile(read func(row []string) error) error {
-	r, err := os.Open(s.path)
-	if err != nil {
-		return err
+func xValues[T, U charts.ScalerConstraint](series []charts.Serie[T, U]) []T {
+	var list []T
+	for _, s := range series {
+		if xs, _, ok := binValues(s); ok {
+			list = append(list, xs...)
+			continue
+		}
+		for _, p := range s.Points {
+			list = append(list, p.X)
+		}
 	}
-	defer r.Close()
+	return list
+}
 
-	rs := csv.NewReader(r)
-	rs.Read()
-	for {
-		row, err := rs.Read()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			return err
+func yValues[T, U charts.ScalerConstraint](series []charts.Serie[T, U]) []U {
+	var list []U
+	for _, s := range series {
+		if _, ys, ok := binValues(s); ok {
+			list = append(list, ys...)
+			continue
+		}
+		if ys, ok := summaryValues(s); ok {
+			list = append(list, ys...)
+			continue
 		}
-		if err := read(row); err != nil {
-			return err
+		list = append(list, baseValues(s)...)
+		for _, p := range s.Points {
+			list = append(list, p.Y)
+			if _, ok := any(p.Y).(string); ok {
+				// sub points of a category are not categories of the axis
+				continue
+			}
+			for _, s := range p.Sub {
+				list = append(list, s.Y)
+			}
 		}
 	}
-	return nil
+	return list
 }
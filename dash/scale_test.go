@@ -0,0 +1,83 @@
+package dash
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/midbel/charts"
+)
+
+func TestLogScale(t *testing.T) {
+	var (
+		rg   = charts.NewRange(0, 300)
+		kind = ScaleType{Kind: ScaleLog, Param: 10}
+	)
+	data := []struct {
+		Name  string
+		Maker ScalerMaker
+		Err   error
+		Min   float64
+	}{
+		{
+			Name:  "auto",
+			Maker: autoScaler{numbers: []float64{0, 10, 1000, -5}},
+			Min:   10,
+		},
+		{
+			Name:  "auto with zero and margin",
+			Maker: autoScaler{numbers: []float64{0, 1, 1000}, zero: true, margin: 0.1},
+			Min:   1,
+		},
+		{
+			Name:  "auto single value",
+			Maker: autoScaler{numbers: []float64{0, 100}},
+			Min:   10,
+		},
+		{
+			Name:  "auto without positive values",
+			Maker: autoScaler{numbers: []float64{0, -10}},
+			Err:   errLog,
+		},
+		{
+			Name:  "list",
+			Maker: ScaleFromList([]string{"1", "1000"}),
+			Min:   1,
+		},
+		{
+			Name:  "list with zero",
+			Maker: ScaleFromList([]string{"0", "1000"}),
+			Err:   errLog,
+		},
+		{
+			Name:  "list with negative",
+			Maker: ScaleFromList([]string{"1000", "-1"}),
+			Err:   errLog,
+		},
+	}
+	for _, d := range data {
+		scale, err := d.Maker.NumberScale(rg, kind, false)
+		if d.Err != nil {
+			if !errors.Is(err, d.Err) {
+				t.Errorf("%s: expected error %q, got %v", d.Name, d.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Name, err)
+			continue
+		}
+		if pos := scale.Scale(d.Min); pos != 0 {
+			t.Errorf("%s: %f should be at the bottom of the scale, got %f", d.Name, d.Min, pos)
+		}
+		values := scale.Values(5)
+		if len(values) < 2 {
+			t.Errorf("%s: not enough ticks: %v", d.Name, values)
+		}
+		for _, v := range values {
+			if pos := scale.Scale(v); math.IsNaN(pos) || math.IsInf(pos, 0) {
+				t.Errorf("%s: invalid position for %f: %f", d.Name, v, pos)
+			}
+		}
+	}
+}
@@ -2,12 +2,17 @@ package dash
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/midbel/slices"
 )
 
-var ErrIndex = errors.New("invalid index")
+var (
+	ErrIndex      = errors.New("invalid index")
+	errUnresolved = errors.New("column names not resolved")
+)
 
 type Indexer interface {
 	columns() []int
@@ -18,6 +23,12 @@ type Selector interface {
 	Indexer
 }
 
+// resolver is implemented by the selectors referring to columns by their name.
+// The names are resolved against the header of the file before reading rows.
+type resolver interface {
+	resolve([]string) (Selector, error)
+}
+
 type combined struct {
 	selectors []Selector
 }
@@ -40,6 +51,23 @@ func (c combined) columns() []int {
 	return list
 }
 
+func (c combined) resolve(header []string) (Selector, error) {
+	list := make([]Selector, len(c.selectors))
+	for i, s := range c.selectors {
+		list[i] = s
+		r, ok := s.(resolver)
+		if !ok {
+			continue
+		}
+		x, err := r.resolve(header)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = x
+	}
+	return Combined(list...), nil
+}
+
 func (c combined) Select(row []string) ([]float64, error) {
 	var list []float64
 	for _, s := range c.selectors {
@@ -138,8 +166,63 @@ func (m multi) Select(row []string) ([]float64, error) {
 	return list, nil
 }
 
+type named struct {
+	names  []string
+	create func([]int) Selector
+}
+
+// SelectNamed gives a selector for columns given by their name in the header.
+// Names that are numbers are used as indexes. The selector given by create
+// with the indexes of the columns is used once the names are resolved.
+func SelectNamed(names []string, create func([]int) Selector) Selector {
+	return named{
+		names:  names,
+		create: create,
+	}
+}
+
+func (n named) columns() []int {
+	return nil
+}
+
+func (n named) Select(row []string) ([]float64, error) {
+	return nil, errUnresolved
+}
+
+func (n named) resolve(header []string) (Selector, error) {
+	list := make([]int, len(n.names))
+	for i, name := range n.names {
+		x, err := columnIndex(header, name)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = x
+	}
+	return n.create(list), nil
+}
+
+func columnIndex(header []string, name string) (int, error) {
+	if i, err := strconv.Atoi(name); err == nil {
+		return i, nil
+	}
+	for i := range header {
+		if strings.TrimSpace(header[i]) == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%s: unknown column (available: %s)", name, strings.Join(header, ", "))
+}
+
+// ExpandRange gives the indexes from fst to lst. The indexes are given in
+// reverse order when lst comes before fst.
 func ExpandRange(fst, lst int) []int {
 	var list []int
+	if fst > lst {
+		for i := fst; i >= lst; i-- {
+			list = append(list, i)
+		}
+		return list
+	}
 	for i := fst; i <= lst; i++ {
 		list = append(list, i)
 	}
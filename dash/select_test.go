@@ -0,0 +1,119 @@
+package dash
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUsingResolve(t *testing.T) {
+	var (
+		header = []string{"Date", "Open", "High", "Low", " Close", "Volume"}
+		row    = []string{"2022-09-01", "1", "2", "3", "4", "5"}
+		single = func(list []int) Selector {
+			return SelectSingle(list[0])
+		}
+		between = func(list []int) Selector {
+			return SelectMulti(ExpandRange(list[0], list[1]))
+		}
+		total = func(list []int) Selector {
+			return SelectSum(ExpandRange(list[0], list[1]))
+		}
+	)
+	data := []struct {
+		Name  string
+		Using Using
+		X     int
+		Want  []float64
+		Err   string
+	}{
+		{
+			Name:  "name",
+			Using: Using{XName: "Date", Y: SelectNamed([]string{"Close"}, single)},
+			X:     0,
+			Want:  []float64{4},
+		},
+		{
+			Name:  "index as name",
+			Using: Using{X: 0, Y: SelectNamed([]string{"High", "3"}, SelectMulti)},
+			Want:  []float64{2, 3},
+		},
+		{
+			Name:  "range",
+			Using: Using{XName: "Date", Y: SelectNamed([]string{"Open", "Low"}, between)},
+			Want:  []float64{1, 2, 3},
+		},
+		{
+			Name:  "reversed range",
+			Using: Using{X: 0, Y: SelectNamed([]string{"Low", "Open"}, between)},
+			Want:  []float64{3, 2, 1},
+		},
+		{
+			Name:  "sum",
+			Using: Using{X: 0, Y: SelectNamed([]string{"Volume", "Close"}, SelectSum)},
+			Want:  []float64{9},
+		},
+		{
+			Name:  "range sum",
+			Using: Using{X: 0, Y: SelectNamed([]string{"Open", "High"}, total)},
+			Want:  []float64{3},
+		},
+		{
+			Name: "combined",
+			Using: Using{X: 0, Y: Combined(
+				SelectSingle(1),
+				SelectNamed([]string{"Volume"}, single),
+			)},
+			Want: []float64{1, 5},
+		},
+		{
+			Name:  "unknown y",
+			Using: Using{X: 0, Y: SelectNamed([]string{"Adj Close"}, single)},
+			Err:   "Adj Close: unknown column (available: Date, Open, High, Low,  Close, Volume)",
+		},
+		{
+			Name:  "unknown x",
+			Using: Using{XName: "date", Y: SelectSingle(1)},
+			Err:   "date: unknown column",
+		},
+		{
+			Name: "unknown in combined",
+			Using: Using{X: 0, Y: Combined(
+				SelectSingle(1),
+				SelectNamed([]string{"Open", "Last"}, between),
+			)},
+			Err: "Last: unknown column",
+		},
+	}
+	for _, d := range data {
+		use, err := d.Using.resolve(header)
+		if d.Err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), d.Err) {
+				t.Errorf("%s: expected error %q, got %v", d.Name, d.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Name, err)
+			continue
+		}
+		if use.X != d.X {
+			t.Errorf("%s: x mismatched: want %d, got %d", d.Name, d.X, use.X)
+		}
+		got, err := use.Y.Select(row)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, d.Want) {
+			t.Errorf("%s: values mismatched: want %v, got %v", d.Name, d.Want, got)
+		}
+	}
+}
+
+func TestSelectNamedUnresolved(t *testing.T) {
+	sel := SelectNamed([]string{"Close"}, SelectMulti)
+	if _, err := sel.Select([]string{"1"}); err == nil {
+		t.Errorf("selecting with unresolved names should fail")
+	}
+}
@@ -2,6 +2,7 @@ package dash
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/midbel/charts"
 )
@@ -18,6 +19,13 @@ const (
 	RenderStep       = "step"
 	RenderStepAfter  = "step-after"
 	RenderStepBefore = "step-before"
+	RenderCubic      = "cubic"
+	RenderMonotone   = "monotone"
+	RenderNatural    = "natural"
+	RenderArea       = "area"
+	RenderAreaStep   = "area-step"
+	RenderAreaBefore = "area-step-before"
+	RenderAreaAfter  = "area-step-after"
 	RenderPie        = "pie"
 	RenderBar        = "bar"
 	RenderSun        = "sun"
@@ -25,6 +33,18 @@ const (
 	RenderNormStack  = "stack-normalize"
 	RenderGroup      = "group"
 	RenderPolar      = "polar"
+	RenderCandle     = "candle"
+	RenderOHLC       = "ohlc"
+	RenderHistogram  = "histogram"
+	RenderBox        = "boxplot"
+	RenderViolin     = "violin"
+	RenderHeatmap    = "heatmap"
+	RenderTreemap    = "treemap"
+	RenderAreaStack  = "area-stack"
+	RenderAreaNorm   = "area-normalize"
+	RenderAreaStream = "area-stream"
+	RenderScatter    = "scatter"
+	RenderBubble     = "bubble"
 )
 
 type Style = charts.Style
@@ -34,19 +54,35 @@ type NumberStyle struct {
 	Ident         string
 	TextPosition  charts.TextPosition
 	IgnoreMissing bool
+	Tension       float64
+	Width         float64
+	Binning       charts.Binning
+	Point         string
+	Size          float64
+	Scheme        string
+	Error         charts.ErrorStyle
+	Axis          string
+	Labels        charts.Labels
+	LabelFormat   string
+	Baseline      float64
 }
 
 func DefaultNumberStyle() NumberStyle {
 	return NumberStyle{
 		Style:         charts.DefaultStyle(),
 		IgnoreMissing: true,
+		Baseline:      math.NaN(),
 	}
 }
 
 type CategoryStyle struct {
 	Style
-	Ident string
-	Width float64
+	Ident       string
+	Width       float64
+	Error       charts.ErrorStyle
+	Axis        string
+	Labels      charts.Labels
+	LabelFormat string
 }
 
 func DefaultCategoryStyle() CategoryStyle {
@@ -67,6 +103,8 @@ type CircularStyle struct {
 	Fill        []string
 	InnerRadius float64
 	OuterRadius float64
+	Labels      charts.Labels
+	LabelFormat string
 }
 
 func DefaultCircularStyle() CircularStyle {
@@ -85,6 +123,105 @@ func (s CircularStyle) Copy() CircularStyle {
 	return x
 }
 
+type HeatmapStyle struct {
+	Style
+	Ident       string
+	Scheme      string
+	Interpolate string
+	Diverging   bool
+	Midpoint    float64
+}
+
+func DefaultHeatmapStyle() HeatmapStyle {
+	style := HeatmapStyle{
+		Style:  charts.DefaultStyle(),
+		Scheme: "viridis",
+	}
+	style.LineColor = "white"
+	return style
+}
+
+type TreemapStyle struct {
+	Style
+	Ident  string
+	Layout string
+}
+
+func DefaultTreemapStyle() TreemapStyle {
+	style := TreemapStyle{
+		Style: charts.DefaultStyle(),
+	}
+	style.FillList = charts.Tableau10
+	style.LineColor = "white"
+	style.FontColor = "white"
+	style.Padding = charts.Padding{
+		Top:    charts.FontSize * 1.5,
+		Right:  2,
+		Bottom: 2,
+		Left:   2,
+	}
+	return style
+}
+
+func (s TreemapStyle) Copy() TreemapStyle {
+	x := s
+	x.FillList = s.FillList.Clone()
+	return x
+}
+
+type PolarStyle struct {
+	Style
+	Ident      string
+	Mode       string
+	Radius     float64
+	Ticks      int
+	TicksStyle string
+	Angular    bool
+	Stacked    bool
+	Point      string
+}
+
+func DefaultPolarStyle() PolarStyle {
+	style := PolarStyle{
+		Style: charts.DefaultStyle(),
+		Ticks: 5,
+	}
+	style.FillList = charts.Tableau10
+	style.FillOpacity = 0.4
+	style.LineWidth = 1.5
+	return style
+}
+
+func (s PolarStyle) Copy() PolarStyle {
+	x := s
+	x.FillList = s.FillList.Clone()
+	return x
+}
+
+func GetPolarType(str string) charts.PolarType {
+	switch str {
+	case "area":
+		return charts.PolarArea
+	case "polygon":
+		return charts.PolarPolygon
+	default:
+		return charts.PolarDefault
+	}
+}
+
+func GetLabelPosition(str string) charts.LabelPosition {
+	switch str {
+	case "inside", "centroid":
+		return charts.LabelInside
+	case "outside":
+		return charts.LabelOutside
+	case "end":
+		return charts.LabelEnd
+	default:
+		return charts.LabelNone
+	}
+}
+
 func GetTextPosition(str string) charts.TextPosition {
 	var pos charts.TextPosition
 	switch str {
@@ -103,11 +240,74 @@ func GetPointFunc(str string) charts.PointFunc {
 		return charts.GetCircle
 	case "square":
 		return charts.GetSquare
+	case "diamond":
+		return charts.GetDiamond
 	default:
 		return nil
 	}
 }
 
+func GetBinStrategy(str string) (charts.BinStrategy, error) {
+	switch str {
+	case "sturges":
+		return charts.BinSturges, nil
+	case "scott":
+		return charts.BinScott, nil
+	case "freedman":
+		return charts.BinFreedman, nil
+	default:
+		return charts.BinSturges, fmt.Errorf("%s: invalid bins (expected number, sturges, scott or freedman)", str)
+	}
+}
+
+func GetBinMode(str string) charts.BinMode {
+	switch str {
+	case "density":
+		return charts.BinDensity
+	case "cumulative":
+		return charts.BinCumulative
+	default:
+		return charts.BinFrequency
+	}
+}
+
+func GetTreemapLayout(str string) charts.TreemapLayout {
+	switch str {
+	case "slice-dice":
+		return charts.TreemapSliceDice
+	case "binary":
+		return charts.TreemapBinary
+	default:
+		return charts.TreemapSquarify
+	}
+}
+
+func GetColorScheme(str string) (charts.Palette, error) {
+	switch str {
+	case "", "viridis":
+		return charts.Viridis, nil
+	case "blues":
+		return charts.Blues, nil
+	case "reds":
+		return charts.Reds, nil
+	case "greens":
+		return charts.Greens, nil
+	case "rdbu":
+		return charts.RdBu, nil
+	case "rdylgn":
+		return charts.RdYlGn, nil
+	default:
+		return nil, fmt.Errorf("%s: invalid scheme (expected viridis, blues, reds, greens, rdbu or rdylgn)", str)
+	}
+}
+
+func GetColorSpace(str string) charts.ColorSpace {
+	if str == "hcl" {
+		return charts.InterpolateHCL
+	}
+	return charts.InterpolateLab
+}
+
 func GetLineType(str string) charts.LineStyle {
 	var i charts.LineStyle
 	switch str {
@@ -129,7 +329,25 @@ func getRenderer[T, U charts.ScalerConstraint](kind string, style any) (charts.R
 	if err != nil {
 		return nil, err
 	}
+	err = checkError(kind, st.Error, RenderLine, RenderStep, RenderStepBefore,
+		RenderStepAfter, RenderCubic, RenderMonotone, RenderNatural, RenderScatter, RenderBubble)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLabels(kind, st.Labels, RenderScatter, RenderBubble); err != nil {
+		return nil, err
+	}
 	switch kind {
+	case RenderCandle, RenderOHLC:
+		return getCandleRenderer[T, U](kind, st), nil
+	case RenderHistogram:
+		return getHistogramRenderer[T, U](kind, st)
+	case RenderAreaStack, RenderAreaNorm, RenderAreaStream:
+		return getStackedAreaRenderer[T, U](kind, st), nil
+	case RenderScatter, RenderBubble:
+		return getPointRenderer[T, U](kind, st)
+	case RenderArea, RenderAreaStep, RenderAreaBefore, RenderAreaAfter:
+		return getAreaRenderer[T, U](kind, st), nil
 	case RenderLine:
 		rdr = charts.Line[T, U]()
 	case RenderStep:
@@ -138,12 +356,141 @@ func getRenderer[T, U charts.ScalerConstraint](kind string, style any) (charts.R
 		rdr = charts.StepBefore[T, U]()
 	case RenderStepAfter:
 		rdr = charts.StepAfter[T, U]()
+	case RenderCubic:
+		rdr = charts.Cubic[T, U](st.Tension)
+	case RenderMonotone:
+		rdr = charts.Monotone[T, U]()
+	case RenderNatural:
+		rdr = charts.Natural[T, U]()
 	default:
 		return nil, fmt.Errorf("%s unrecognized chart type", kind)
 	}
 	rdr.Style = st.Style
 	rdr.Text = st.TextPosition
 	rdr.IgnoreMissing = st.IgnoreMissing
+	rdr.Error = st.Error
+	return rdr, nil
+}
+
+func getCandleRenderer[T, U charts.ScalerConstraint](kind string, st NumberStyle) charts.Renderer[T, U] {
+	var up, down string
+	if len(st.FillList) >= 2 {
+		up, down = st.FillList[0], st.FillList[1]
+	}
+	if kind == RenderOHLC {
+		return charts.OHLCRenderer[T, U]{
+			Style: st.Style,
+			Width: st.Width,
+			Up:    up,
+			Down:  down,
+		}
+	}
+	return charts.CandleRenderer[T, U]{
+		Style: st.Style,
+		Width: st.Width,
+		Up:    up,
+		Down:  down,
+	}
+}
+
+func getPointRenderer[T, U charts.ScalerConstraint](kind string, st NumberStyle) (charts.Renderer[T, U], error) {
+	rdr := charts.PointRenderer[T, U]{
+		Fill:   st.LineColor,
+		Point:  GetPointFunc(st.Point),
+		Size:   st.Size,
+		Error:  st.Error,
+		Labels: st.Labels,
+	}
+	if len(st.FillList) > 0 {
+		rdr.Fill = st.FillList.Curr()
+	}
+	if kind == RenderBubble {
+		rdr.MaxSize = st.Size
+		if rdr.MaxSize <= 0 {
+			rdr.MaxSize = charts.DefaultSize * 10
+		}
+	}
+	colors, err := GetColorScheme(st.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	rdr.Colors.Colors = colors
+	return rdr, nil
+}
+
+func getAreaRenderer[T, U charts.ScalerConstraint](kind string, st NumberStyle) charts.Renderer[T, U] {
+	var rdr charts.AreaRenderer[T, U]
+	switch kind {
+	case RenderAreaStep:
+		rdr = charts.AreaStep[T, U]()
+	case RenderAreaBefore:
+		rdr = charts.AreaStepBefore[T, U]()
+	case RenderAreaAfter:
+		rdr = charts.AreaStepAfter[T, U]()
+	default:
+		rdr = charts.Area[T, U]()
+	}
+	rdr.Style = st.Style
+	rdr.Text = st.TextPosition
+	rdr.IgnoreMissing = st.IgnoreMissing
+	rdr.Baseline = st.Baseline
+	return rdr
+}
+
+func getStackedAreaRenderer[T, U charts.ScalerConstraint](kind string, st NumberStyle) charts.Renderer[T, U] {
+	rdr := charts.StackedAreaRenderer[T, U]{
+		Style: st.Style,
+	}
+	switch kind {
+	case RenderAreaNorm:
+		rdr.Offset = charts.StackExpand
+	case RenderAreaStream:
+		rdr.Offset = charts.StackWiggle
+	default:
+		rdr.Offset = charts.StackZero
+	}
+	return rdr
+}
+
+func getHistogramRenderer[T, U charts.ScalerConstraint](kind string, st NumberStyle) (charts.Renderer[T, U], error) {
+	var rdr any = charts.HistogramRenderer[float64, float64]{
+		Style:   st.Style,
+		Binning: st.Binning,
+	}
+	if r, ok := rdr.(charts.Renderer[T, U]); ok {
+		return r, nil
+	}
+	return nil, fmt.Errorf("%s renderer only available for number chart", kind)
+}
+
+func getHeatmapRenderer[T, U charts.ScalerConstraint](kind string, style any, min, max float64) (charts.Renderer[T, U], error) {
+	st, ok := style.(HeatmapStyle)
+	if !ok || kind != RenderHeatmap {
+		return nil, fmt.Errorf("invalid style given for %s renderer", kind)
+	}
+	colors, err := GetColorScheme(st.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	if len(st.FillList) > 0 {
+		colors = st.FillList
+	}
+	var scale charts.ColorScale
+	if st.Diverging {
+		scale = charts.DivergingScale(min, st.Midpoint, max, colors...)
+	} else {
+		scale = charts.SequentialScale(min, max, colors...)
+	}
+	scale.Space = GetColorSpace(st.Interpolate)
+	if err := scale.Check(); err != nil {
+		return nil, err
+	}
+
+	rdr := charts.HeatmapRenderer[T, U]{
+		Style: st.Style,
+		Scale: scale,
+	}
+	rdr.FillList = nil
 	return rdr, nil
 }
 
@@ -155,12 +502,16 @@ func getCircularRenderer[T ~string, U float64](kind string, style any) (charts.R
 	if err != nil {
 		return nil, err
 	}
+	if err := checkLabels(kind, st.Labels, RenderPie); err != nil {
+		return nil, err
+	}
 	switch kind {
 	case RenderPie:
 		rdr = charts.PieRenderer[T, U]{
 			Style:       st.Style,
 			InnerRadius: st.InnerRadius,
 			OuterRadius: st.OuterRadius,
+			Labels:      st.Labels,
 		}
 	case RenderSun:
 		rdr = charts.SunburstRenderer[T, U]{
@@ -174,10 +525,46 @@ func getCircularRenderer[T ~string, U float64](kind string, style any) (charts.R
 	return rdr, nil
 }
 
+func getPolarRenderer[T ~string, U float64](kind string, style any) (charts.Renderer[T, U], error) {
+	st, ok := style.(PolarStyle)
+	if !ok {
+		return nil, fmt.Errorf("invalid style given for %s renderer", kind)
+	}
+	rdr := charts.PolarRenderer[T, U]{
+		Style:      st.Style,
+		Radius:     st.Radius,
+		Ticks:      st.Ticks,
+		TicksStyle: GetLineType(st.TicksStyle),
+		Type:       GetPolarType(st.Mode),
+		Stacked:    st.Stacked,
+		Angular:    st.Angular,
+		Point:      GetPointFunc(st.Point),
+	}
+	return rdr, nil
+}
+
+func getTreemapRenderer[T ~string, U float64](kind string, style any) (charts.Renderer[T, U], error) {
+	st, ok := style.(TreemapStyle)
+	if !ok {
+		return nil, fmt.Errorf("invalid style given for %s renderer", kind)
+	}
+	rdr := charts.TreemapRenderer[T, U]{
+		Style:  st.Style,
+		Layout: GetTreemapLayout(st.Layout),
+	}
+	return rdr, nil
+}
+
 func getCategoryRenderer[T ~string, U float64](kind string, style any) (charts.Renderer[T, U], error) {
 	if kind == RenderPie || kind == RenderSun {
 		return getCircularRenderer[T, U](kind, style)
 	}
+	if kind == RenderTreemap {
+		return getTreemapRenderer[T, U](kind, style)
+	}
+	if kind == RenderPolar {
+		return getPolarRenderer[T, U](kind, style)
+	}
 	var (
 		rdr     charts.Renderer[T, U]
 		st, err = getCategoryStyle(kind, style)
@@ -185,22 +572,38 @@ func getCategoryRenderer[T ~string, U float64](kind string, style any) (charts.R
 	if err != nil {
 		return nil, err
 	}
+	if err := checkError(kind, st.Error, RenderBar); err != nil {
+		return nil, err
+	}
+	if err := checkLabels(kind, st.Labels, RenderBar, RenderGroup, RenderStack, RenderNormStack); err != nil {
+		return nil, err
+	}
 	switch kind {
 	case RenderBar:
 		rdr = charts.BarRenderer[T, U]{
-			Style: st.Style,
-			Width: st.Width,
+			Style:  st.Style,
+			Width:  st.Width,
+			Error:  st.Error,
+			Labels: st.Labels,
 		}
 	case RenderGroup:
 		rdr = charts.GroupRenderer[T, U]{
-			Style: st.Style,
-			Width: st.Width,
+			Style:  st.Style,
+			Width:  st.Width,
+			Labels: st.Labels,
 		}
 	case RenderStack, RenderNormStack:
 		rdr = charts.StackedRenderer[T, U]{
 			Style:     st.Style,
 			Width:     st.Width,
 			Normalize: kind == RenderNormStack,
+			Labels:    st.Labels,
+		}
+	case RenderBox, RenderViolin:
+		rdr = charts.BoxRenderer[T, U]{
+			Style:  st.Style,
+			Width:  st.Width,
+			Violin: kind == RenderViolin,
 		}
 	default:
 		return nil, fmt.Errorf("%s unrecognized chart type", kind)
@@ -208,6 +611,75 @@ func getCategoryRenderer[T ~string, U float64](kind string, style any) (charts.R
 	return rdr, nil
 }
 
+func getHorizontalRenderer[T ~float64, U ~string](kind string, style any) (charts.Renderer[T, U], error) {
+	var (
+		rdr     charts.Renderer[T, U]
+		st, err = getCategoryStyle(kind, style)
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkError(kind, st.Error, RenderBar); err != nil {
+		return nil, err
+	}
+	if err := checkLabels(kind, st.Labels, RenderBar, RenderGroup, RenderStack, RenderNormStack); err != nil {
+		return nil, err
+	}
+	switch kind {
+	case RenderBar:
+		rdr = charts.HorizontalBarRenderer[T, U]{
+			Style:  st.Style,
+			Width:  st.Width,
+			Error:  st.Error,
+			Labels: st.Labels,
+		}
+	case RenderGroup:
+		rdr = charts.HorizontalGroupRenderer[T, U]{
+			Style:  st.Style,
+			Width:  st.Width,
+			Labels: st.Labels,
+		}
+	case RenderStack, RenderNormStack:
+		rdr = charts.HorizontalStackedRenderer[T, U]{
+			Style:     st.Style,
+			Width:     st.Width,
+			Normalize: kind == RenderNormStack,
+			Labels:    st.Labels,
+		}
+	default:
+		return nil, fmt.Errorf("%s renderer not available for horizontal chart", kind)
+	}
+	return rdr, nil
+}
+
+// checkError reports an error when the bounds of the points are requested for
+// a renderer not drawing them.
+func checkError(kind string, style charts.ErrorStyle, accepted ...string) error {
+	if !style.Visible {
+		return nil
+	}
+	for _, k := range accepted {
+		if k == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s renderer can not draw error bars", kind)
+}
+
+// checkLabels reports an error when labels are requested for a renderer not
+// writing them.
+func checkLabels(kind string, labels charts.Labels, accepted ...string) error {
+	if !labels.Visible() {
+		return nil
+	}
+	for _, k := range accepted {
+		if k == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s renderer can not draw labels", kind)
+}
+
 func getNumberStyle(kind string, style any) (NumberStyle, error) {
 	st, ok := style.(NumberStyle)
 	if !ok {
@@ -0,0 +1,428 @@
+package dash
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/midbel/buddy/ast"
+	"github.com/midbel/buddy/eval"
+	"github.com/midbel/buddy/types"
+	"github.com/midbel/charts"
+)
+
+const (
+	TransformFilter    = "filter"
+	TransformSort      = "sort"
+	TransformDedupe    = "dedupe"
+	TransformAggregate = "aggregate"
+	TransformCumSum    = "cumsum"
+	TransformDiff      = "diff"
+	TransformChange    = "pct-change"
+	TransformRolling   = "rolling"
+)
+
+const (
+	AggrSum    = "sum"
+	AggrMean   = "mean"
+	AggrMin    = "min"
+	AggrMax    = "max"
+	AggrCount  = "count"
+	AggrMedian = "median"
+)
+
+const (
+	SortX = "x"
+	SortY = "y"
+)
+
+// Transform is one step of a pipeline. Func is the function used by
+// aggregate and rolling, Field and Desc give the order of sort and Expr the
+// predicate of filter.
+type Transform struct {
+	Type   string
+	Func   string
+	Field  string
+	Desc   bool
+	Window int
+	Expr   ast.Expression
+}
+
+// Pipeline runs its transforms, in order, on the points loaded from its
+// source. The operations on the values apply to the y value of the points
+// and to their sub values.
+type Pipeline struct {
+	Source     DataSource
+	Transforms []Transform
+}
+
+func (p Pipeline) TimeSerie(timefmt string, x TimeScale, y FloatScale) (TimeSerie, error) {
+	ser, err := p.Source.TimeSerie(timefmt, x, y)
+	if err != nil {
+		return ser, err
+	}
+	ser.Points, err = transformPoints(ser.Points, p.Transforms)
+	return ser, err
+}
+
+func (p Pipeline) NumberSerie(x FloatScale, y FloatScale) (NumberSerie, error) {
+	ser, err := p.Source.NumberSerie(x, y)
+	if err != nil {
+		return ser, err
+	}
+	ser.Points, err = transformPoints(ser.Points, p.Transforms)
+	return ser, err
+}
+
+func (p Pipeline) CategorySerie(x StringScale, y FloatScale) (CategorySerie, error) {
+	ser, err := p.Source.CategorySerie(x, y)
+	if err != nil {
+		return ser, err
+	}
+	ser.Points, err = transformPoints(ser.Points, p.Transforms)
+	return ser, err
+}
+
+func transformPoints[T charts.ScalerConstraint](points []charts.Point[T, float64], list []Transform) ([]charts.Point[T, float64], error) {
+	var err error
+	for _, t := range list {
+		switch t.Type {
+		case TransformFilter:
+			points, err = filterPoints(points, t.Expr)
+		case TransformSort:
+			points = sortPoints(points, t.Field, t.Desc)
+		case TransformDedupe:
+			points = dedupePoints(points)
+		case TransformAggregate:
+			points = aggregatePoints(points, t.Func)
+		case TransformCumSum:
+			points = mapValues(points, cumulValues)
+		case TransformDiff:
+			points = mapValues(points, diffValues)
+		case TransformChange:
+			points = mapValues(points, changeValues)
+		case TransformRolling:
+			points = mapValues(points, func(values []float64) []float64 {
+				return rollingValues(values, t.Func, t.Window)
+			})
+		default:
+			err = fmt.Errorf("%s: unsupported transform", t.Type)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+// filterPoints keeps the points for which the predicate is true. x is bound
+// to the x value of the point and y to its y value. For time, x is given in
+// seconds since the first point, as for Expr, and t in seconds since the
+// epoch.
+func filterPoints[T charts.ScalerConstraint](points []charts.Point[T, float64], expr ast.Expression) ([]charts.Point[T, float64], error) {
+	var (
+		list []charts.Point[T, float64]
+		fst  = firstTime(points)
+	)
+	for _, pt := range points {
+		env := types.EmptyEnv()
+		if when, ok := any(pt.X).(time.Time); ok {
+			defineValue(env, "x", when.Sub(fst).Seconds())
+			defineValue(env, "t", float64(when.Unix()))
+		} else {
+			defineValue(env, "x", pt.X)
+		}
+		defineValue(env, "y", pt.Y)
+		res, err := eval.Execute(expr, env)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil && isTrue(res.String()) {
+			list = append(list, pt)
+		}
+	}
+	return list, nil
+}
+
+// firstTime gives the earliest x value of the points when they are times.
+func firstTime[T charts.ScalerConstraint](points []charts.Point[T, float64]) time.Time {
+	var fst time.Time
+	for i, pt := range points {
+		when, ok := any(pt.X).(time.Time)
+		if !ok {
+			break
+		}
+		if i == 0 || when.Before(fst) {
+			fst = when
+		}
+	}
+	return fst
+}
+
+func isTrue(str string) bool {
+	if ok, err := strconv.ParseBool(str); err == nil {
+		return ok
+	}
+	val, err := strconv.ParseFloat(str, 64)
+	return err == nil && val != 0 && !math.IsNaN(val)
+}
+
+func sortPoints[T charts.ScalerConstraint](points []charts.Point[T, float64], field string, desc bool) []charts.Point[T, float64] {
+	list := append([]charts.Point[T, float64]{}, points...)
+	less := func(i, j int) bool {
+		if field == SortY {
+			return list[i].Y < list[j].Y
+		}
+		return compareX(list[i].X, list[j].X) < 0
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if desc {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+	return list
+}
+
+// dedupePoints keeps the first point of the points sharing the same x value.
+func dedupePoints[T charts.ScalerConstraint](points []charts.Point[T, float64]) []charts.Point[T, float64] {
+	var (
+		list []charts.Point[T, float64]
+		seen = make(map[any]struct{})
+	)
+	for _, pt := range points {
+		k := keyX(pt.X)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		list = append(list, pt)
+	}
+	return list
+}
+
+// aggregatePoints merges the points sharing the same x value into one point,
+// in the order of their first occurrence. Sub values are aggregated when all
+// the points of a group have the same number of them.
+func aggregatePoints[T charts.ScalerConstraint](points []charts.Point[T, float64], fn string) []charts.Point[T, float64] {
+	var (
+		groups [][]charts.Point[T, float64]
+		index  = make(map[any]int)
+	)
+	for _, pt := range points {
+		k := keyX(pt.X)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], pt)
+	}
+	list := make([]charts.Point[T, float64], 0, len(groups))
+	for _, group := range groups {
+		var (
+			pt     = group[0]
+			values = make([]float64, len(group))
+		)
+		for i := range group {
+			values[i] = group[i].Y
+		}
+		pt.Y = aggregate(values, fn)
+		if n := subColumns(group); n > 0 {
+			pt.Sub = append([]charts.Point[T, float64]{}, pt.Sub...)
+			for j := 0; j < n; j++ {
+				for i := range group {
+					values[i] = group[i].Sub[j].Y
+				}
+				pt.Sub[j].Y = aggregate(values, fn)
+			}
+		}
+		list = append(list, pt)
+	}
+	return list
+}
+
+// mapValues replaces the y values of the points and their sub values by the
+// values given by fn. When fn gives fewer values than it gets, the first
+// points are dropped.
+func mapValues[T charts.ScalerConstraint](points []charts.Point[T, float64], fn func([]float64) []float64) []charts.Point[T, float64] {
+	values := make([]float64, len(points))
+	for i := range points {
+		values[i] = points[i].Y
+	}
+	values = fn(values)
+
+	var (
+		skip = len(points) - len(values)
+		list = append([]charts.Point[T, float64]{}, points[skip:]...)
+	)
+	for i := range list {
+		list[i].Y = values[i]
+		if len(list[i].Sub) > 0 {
+			list[i].Sub = append([]charts.Point[T, float64]{}, list[i].Sub...)
+		}
+	}
+	for j, n := 0, subColumns(points); j < n; j++ {
+		values = make([]float64, len(points))
+		for i := range points {
+			values[i] = points[i].Sub[j].Y
+		}
+		values = fn(values)
+		for i := range list {
+			list[i].Sub[j].Y = values[i]
+		}
+	}
+	return list
+}
+
+// subColumns gives the number of sub values of the points, zero when they
+// do not all have the same number of sub values.
+func subColumns[T charts.ScalerConstraint](points []charts.Point[T, float64]) int {
+	if len(points) == 0 {
+		return 0
+	}
+	n := len(points[0].Sub)
+	for _, pt := range points[1:] {
+		if len(pt.Sub) != n {
+			return 0
+		}
+	}
+	return n
+}
+
+func cumulValues(values []float64) []float64 {
+	var (
+		list  = make([]float64, len(values))
+		total float64
+	)
+	for i, v := range values {
+		total += v
+		list[i] = total
+	}
+	return list
+}
+
+func diffValues(values []float64) []float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	list := make([]float64, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		list[i-1] = values[i] - values[i-1]
+	}
+	return list
+}
+
+// changeValues gives the change in percent of each value from the previous
+// one. The change is NaN when the previous value is zero.
+func changeValues(values []float64) []float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	list := make([]float64, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		if values[i-1] == 0 {
+			list[i-1] = math.NaN()
+			continue
+		}
+		list[i-1] = (values[i] - values[i-1]) / values[i-1] * 100
+	}
+	return list
+}
+
+// rollingValues gives the values of fn over a window ending at each value.
+// The values without a full window are dropped.
+func rollingValues(values []float64, fn string, window int) []float64 {
+	if window < 1 {
+		window = 1
+	}
+	if len(values) < window {
+		return nil
+	}
+	list := make([]float64, 0, len(values)-window+1)
+	for i := window; i <= len(values); i++ {
+		list = append(list, aggregate(values[i-window:i], fn))
+	}
+	return list
+}
+
+func aggregate(values []float64, fn string) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	switch fn {
+	case AggrCount:
+		return float64(len(values))
+	case AggrMean:
+		return aggregate(values, AggrSum) / float64(len(values))
+	case AggrMin:
+		res := values[0]
+		for _, v := range values[1:] {
+			res = math.Min(res, v)
+		}
+		return res
+	case AggrMax:
+		res := values[0]
+		for _, v := range values[1:] {
+			res = math.Max(res, v)
+		}
+		return res
+	case AggrMedian:
+		list := append([]float64{}, values...)
+		sort.Float64s(list)
+		mid := len(list) / 2
+		if len(list)%2 == 0 {
+			return (list[mid-1] + list[mid]) / 2
+		}
+		return list[mid]
+	default:
+		var total float64
+		for _, v := range values {
+			total += v
+		}
+		return total
+	}
+}
+
+func compareX[T charts.ScalerConstraint](a, b T) int {
+	switch a := any(a).(type) {
+	case time.Time:
+		b := any(b).(time.Time)
+		if a.Before(b) {
+			return -1
+		} else if a.After(b) {
+			return 1
+		}
+		return 0
+	case float64:
+		b := any(b).(float64)
+		if a < b {
+			return -1
+		} else if a > b {
+			return 1
+		}
+		return 0
+	case string:
+		b := any(b).(string)
+		if a < b {
+			return -1
+		} else if a > b {
+			return 1
+		}
+		return 0
+	default:
+		return 0
+	}
+}
+
+// keyX gives a key usable in a map for the x value of a point. Times are
+// compared by their instant.
+func keyX[T charts.ScalerConstraint](x T) any {
+	if when, ok := any(x).(time.Time); ok {
+		return when.UnixNano()
+	}
+	return x
+}
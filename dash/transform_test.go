@@ -0,0 +1,228 @@
+package dash
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/midbel/charts"
+)
+
+func TestAggregate(t *testing.T) {
+	data := []struct {
+		Func   string
+		Values []float64
+		Want   float64
+	}{
+		{Func: AggrSum, Values: []float64{1, 2, 3, 4}, Want: 10},
+		{Func: AggrMean, Values: []float64{1, 2, 3, 4}, Want: 2.5},
+		{Func: AggrMin, Values: []float64{3, -1, 2}, Want: -1},
+		{Func: AggrMax, Values: []float64{3, -1, 2}, Want: 3},
+		{Func: AggrCount, Values: []float64{3, -1, 2}, Want: 3},
+		{Func: AggrMedian, Values: []float64{5, 1, 3}, Want: 3},
+		{Func: AggrMedian, Values: []float64{4, 1, 3, 2}, Want: 2.5},
+		{Func: AggrSum, Values: nil, Want: math.NaN()},
+	}
+	for _, d := range data {
+		got := aggregate(d.Values, d.Func)
+		if !equalValues([]float64{got}, []float64{d.Want}) {
+			t.Errorf("%s(%v): want %f, got %f", d.Func, d.Values, d.Want, got)
+		}
+	}
+}
+
+func TestTransformValues(t *testing.T) {
+	data := []struct {
+		Name   string
+		Func   func([]float64) []float64
+		Values []float64
+		Want   []float64
+	}{
+		{
+			Name:   "cumsum",
+			Func:   cumulValues,
+			Values: []float64{1, 2, 3, -4},
+			Want:   []float64{1, 3, 6, 2},
+		},
+		{
+			Name:   "diff",
+			Func:   diffValues,
+			Values: []float64{1, 4, 2, 2},
+			Want:   []float64{3, -2, 0},
+		},
+		{
+			Name:   "diff",
+			Func:   diffValues,
+			Values: nil,
+			Want:   nil,
+		},
+		{
+			Name:   "pct-change",
+			Func:   changeValues,
+			Values: []float64{10, 15, 0, 5, 4},
+			Want:   []float64{50, -100, math.NaN(), -20},
+		},
+		{
+			Name: "rolling",
+			Func: func(values []float64) []float64 {
+				return rollingValues(values, AggrMean, 2)
+			},
+			Values: []float64{1, 3, 5, 9},
+			Want:   []float64{2, 4, 7},
+		},
+		{
+			Name: "rolling",
+			Func: func(values []float64) []float64 {
+				return rollingValues(values, AggrMax, 3)
+			},
+			Values: []float64{4, 1, 2, 6, 3},
+			Want:   []float64{4, 6, 6},
+		},
+		{
+			Name: "rolling",
+			Func: func(values []float64) []float64 {
+				return rollingValues(values, AggrSum, 5)
+			},
+			Values: []float64{1, 2},
+			Want:   nil,
+		},
+	}
+	for _, d := range data {
+		got := d.Func(d.Values)
+		if !equalValues(got, d.Want) {
+			t.Errorf("%s(%v): want %v, got %v", d.Name, d.Values, d.Want, got)
+		}
+	}
+}
+
+func TestTransformPoints(t *testing.T) {
+	points := []charts.Point[string, float64]{
+		{X: "b", Y: 2},
+		{X: "a", Y: 1},
+		{X: "b", Y: 4},
+		{X: "c", Y: 3},
+	}
+	data := []struct {
+		Name string
+		Transform
+		Want []charts.Point[string, float64]
+	}{
+		{
+			Name:      "dedupe",
+			Transform: Transform{Type: TransformDedupe},
+			Want: []charts.Point[string, float64]{
+				{X: "b", Y: 2},
+				{X: "a", Y: 1},
+				{X: "c", Y: 3},
+			},
+		},
+		{
+			Name:      "aggregate",
+			Transform: Transform{Type: TransformAggregate, Func: AggrSum},
+			Want: []charts.Point[string, float64]{
+				{X: "b", Y: 6},
+				{X: "a", Y: 1},
+				{X: "c", Y: 3},
+			},
+		},
+		{
+			Name:      "sort x",
+			Transform: Transform{Type: TransformSort, Field: SortX},
+			Want: []charts.Point[string, float64]{
+				{X: "a", Y: 1},
+				{X: "b", Y: 2},
+				{X: "b", Y: 4},
+				{X: "c", Y: 3},
+			},
+		},
+		{
+			Name:      "sort y desc",
+			Transform: Transform{Type: TransformSort, Field: SortY, Desc: true},
+			Want: []charts.Point[string, float64]{
+				{X: "b", Y: 4},
+				{X: "c", Y: 3},
+				{X: "b", Y: 2},
+				{X: "a", Y: 1},
+			},
+		},
+		{
+			Name:      "cumsum",
+			Transform: Transform{Type: TransformCumSum},
+			Want: []charts.Point[string, float64]{
+				{X: "b", Y: 2},
+				{X: "a", Y: 3},
+				{X: "b", Y: 7},
+				{X: "c", Y: 10},
+			},
+		},
+	}
+	for _, d := range data {
+		got, err := transformPoints(points, []Transform{d.Transform})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, d.Want) {
+			t.Errorf("%s: points mismatched: want %v, got %v", d.Name, d.Want, got)
+		}
+	}
+}
+
+func TestTransformSubPoints(t *testing.T) {
+	points := []charts.Point[string, float64]{
+		{X: "a", Y: 1, Sub: []charts.Point[string, float64]{{X: "a", Y: 1}, {X: "a", Y: 10}}},
+		{X: "a", Y: 2, Sub: []charts.Point[string, float64]{{X: "a", Y: 3}, {X: "a", Y: 20}}},
+		{X: "b", Y: 5, Sub: []charts.Point[string, float64]{{X: "b", Y: 4}, {X: "b", Y: 30}}},
+	}
+	got, err := transformPoints(points, []Transform{
+		{Type: TransformAggregate, Func: AggrMax},
+		{Type: TransformDiff},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []charts.Point[string, float64]{
+		{X: "b", Y: 3, Sub: []charts.Point[string, float64]{{X: "b", Y: 1}, {X: "b", Y: 10}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("points mismatched: want %v, got %v", want, got)
+	}
+	if points[0].Sub[0].Y != 1 {
+		t.Errorf("sub values of the source points should not be modified")
+	}
+}
+
+func TestDedupeTimes(t *testing.T) {
+	var (
+		when   = time.Date(2022, 1, 3, 12, 0, 0, 0, time.UTC)
+		other  = when.In(time.FixedZone("CET", 3600))
+		points = []charts.Point[time.Time, float64]{
+			{X: when, Y: 1},
+			{X: other, Y: 2},
+			{X: when.Add(time.Hour), Y: 3},
+		}
+	)
+	got := dedupePoints(points)
+	if len(got) != 2 || got[0].Y != 1 || got[1].Y != 3 {
+		t.Errorf("times should be compared by their instant: got %v", got)
+	}
+}
+
+func equalValues(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				return false
+			}
+			continue
+		}
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/midbel/buddy/parse"
 	"github.com/midbel/charts"
 	"github.com/midbel/charts/dash"
+	"github.com/midbel/charts/output"
 	"github.com/midbel/slices"
 )
 
@@ -45,6 +46,21 @@ type Decoder struct {
 	Step       dash.NumberStyle
 	StepBefore dash.NumberStyle
 	StepAfter  dash.NumberStyle
+	Cubic      dash.NumberStyle
+	Monotone   dash.NumberStyle
+	Natural    dash.NumberStyle
+	Area       dash.NumberStyle
+	AreaStep   dash.NumberStyle
+	AreaBefore dash.NumberStyle
+	AreaAfter  dash.NumberStyle
+	Candle     dash.NumberStyle
+	OHLC       dash.NumberStyle
+	Histogram  dash.NumberStyle
+	AreaStack  dash.NumberStyle
+	AreaNorm   dash.NumberStyle
+	AreaStream dash.NumberStyle
+	Scatter    dash.NumberStyle
+	Bubble     dash.NumberStyle
 
 	Pie dash.CircularStyle
 	Sun dash.CircularStyle
@@ -53,6 +69,12 @@ type Decoder struct {
 	Group     dash.CategoryStyle
 	Stack     dash.CategoryStyle
 	NormStack dash.CategoryStyle
+	Box       dash.CategoryStyle
+	Violin    dash.CategoryStyle
+
+	Heatmap dash.HeatmapStyle
+	Treemap dash.TreemapStyle
+	Polar   dash.PolarStyle
 
 	scan *Scanner
 	curr Token
@@ -72,12 +94,32 @@ func NewDecoder(r io.Reader) *Decoder {
 		Step:       dash.DefaultNumberStyle(),
 		StepBefore: dash.DefaultNumberStyle(),
 		StepAfter:  dash.DefaultNumberStyle(),
+		Cubic:      dash.DefaultNumberStyle(),
+		Monotone:   dash.DefaultNumberStyle(),
+		Natural:    dash.DefaultNumberStyle(),
+		Area:       dash.DefaultNumberStyle(),
+		AreaStep:   dash.DefaultNumberStyle(),
+		AreaBefore: dash.DefaultNumberStyle(),
+		AreaAfter:  dash.DefaultNumberStyle(),
+		Candle:     dash.DefaultNumberStyle(),
+		OHLC:       dash.DefaultNumberStyle(),
+		Histogram:  dash.DefaultNumberStyle(),
+		AreaStack:  dash.DefaultNumberStyle(),
+		AreaNorm:   dash.DefaultNumberStyle(),
+		AreaStream: dash.DefaultNumberStyle(),
+		Scatter:    dash.DefaultNumberStyle(),
+		Bubble:     dash.DefaultNumberStyle(),
 		Pie:        dash.DefaultCircularStyle(),
 		Sun:        dash.DefaultCircularStyle(),
 		Bar:        dash.DefaultCategoryStyle(),
 		Group:      dash.DefaultCategoryStyle(),
 		Stack:      dash.DefaultCategoryStyle(),
 		NormStack:  dash.DefaultCategoryStyle(),
+		Box:        dash.DefaultCategoryStyle(),
+		Violin:     dash.DefaultCategoryStyle(),
+		Heatmap:    dash.DefaultHeatmapStyle(),
+		Treemap:    dash.DefaultTreemapStyle(),
+		Polar:      dash.DefaultPolarStyle(),
 	}
 	if r, ok := r.(interface{ Name() string }); ok {
 		d.file = r.Name()
@@ -156,6 +198,9 @@ func (d *Decoder) decodeElement(cfg *dash.Config) (dash.Element, error) {
 	if err := d.decodeUsing(&el.Using); err != nil {
 		return el, err
 	}
+	if el.Data, err = d.decodeTransform(el.Data); err != nil {
+		return el, err
+	}
 	if err := d.expectKw(kwAs); err != nil {
 		return el, err
 	}
@@ -198,6 +243,8 @@ func (d *Decoder) decodeBody(cfg *dash.Config, accept func(Token) bool) error {
 			err = d.decodeDeclare()
 		case kwUse:
 			err = d.decodeUse(cfg)
+		case kwAnnotate:
+			err = d.decodeAnnotate(cfg)
 		default:
 			err = d.decodeError(fmt.Sprintf("unexpected %q keyword", d.curr.Literal))
 		}
@@ -224,6 +271,9 @@ func (d *Decoder) decodeUse(cfg *dash.Config) error {
 	if el.Data, err = d.files.Resolve(el.Ident); err != nil {
 		return err
 	}
+	if el.Data, err = d.decodeTransform(el.Data); err != nil {
+		return err
+	}
 	if err := d.expectKw(kwAs); err != nil {
 		return err
 	}
@@ -244,6 +294,60 @@ func (d *Decoder) decodeUse(cfg *dash.Config) error {
 	return err
 }
 
+func (d *Decoder) decodeAnnotate(cfg *dash.Config) error {
+	d.next()
+	var (
+		note = dash.Annotation{
+			Style: dash.DefaultAnnotationStyle(),
+		}
+		err error
+	)
+	if note.Type, err = d.getString(); err != nil {
+		return err
+	}
+	switch note.Type {
+	case dash.AnnotateHLine, dash.AnnotateVLine, dash.AnnotateHSpan, dash.AnnotateVSpan, dash.AnnotatePoint:
+	default:
+		return d.decodeError(fmt.Sprintf("%s: unsupported annotation", note.Type))
+	}
+	for {
+		str, err := d.getString()
+		if err != nil {
+			return err
+		}
+		note.Values = append(note.Values, str)
+		if !d.is(Comma) {
+			break
+		}
+		d.next()
+	}
+	if !d.is(EOL) && !d.done() && d.expectKw(kwWith) != nil {
+		if note.Text, err = d.getString(); err != nil {
+			return err
+		}
+	}
+	if err := d.expectKw(kwWith); err == nil {
+		d.next()
+		err = d.decodeWith(func() error {
+			cmd := d.curr.Literal
+			d.next()
+			ok, err := d.decodeGlobalStyle(cmd, &note.Style)
+			if !ok {
+				err = d.optionError("annotate")
+			}
+			if err == nil {
+				err = d.eol()
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cfg.Annotations = append(cfg.Annotations, note)
+	return d.eol()
+}
+
 func (d *Decoder) decodeAt(cfg *dash.Config) error {
 	d.next()
 	var (
@@ -417,16 +521,36 @@ func (d *Decoder) decodeSet(cfg *dash.Config) error {
 		cfg.Y.Type, err = d.getType()
 	case "ydomain":
 		cfg.Y.Scaler, err = d.decodeScaler()
+	case "y2domain":
+		cfg.Y2.Scaler, err = d.decodeScaler()
+	case "xscale":
+		cfg.X.Scale, err = d.decodeScaleType()
+	case "yscale":
+		cfg.Y.Scale, err = d.decodeScaleType()
+	case "y2scale":
+		cfg.Y2.Scale, err = d.decodeScaleType()
 	case "xticks":
 		return d.decodeTicks(&cfg.X.Domain)
 	case "yticks":
 		return d.decodeTicks(&cfg.Y.Domain)
+	case "y2ticks":
+		return d.decodeTicks(&cfg.Y2.Domain)
 	case "style":
 
+	case "format":
+		cfg.Format, err = d.getString()
+		switch cfg.Format {
+		case output.FormatSVG, output.FormatPNG, output.FormatPDF, output.FormatHTML:
+		default:
+			err = fmt.Errorf("%s: unsupported output format", cfg.Format)
+		}
 	case "timefmt":
 		cfg.TimeFormat, err = d.getString()
 	case "delimiter":
-		cfg.Delimiter, err = d.getString()
+		var str string
+		if str, err = d.getString(); err == nil {
+			cfg.Delimiter = dash.GetDelimiter(str)
+		}
 	case "legend":
 		return d.decodeLegend(cfg)
 	case "theme":
@@ -443,6 +567,51 @@ func (d *Decoder) decodeSet(cfg *dash.Config) error {
 	case dash.RenderStepBefore:
 		err = d.decodeNumberStyle(&d.StepBefore)
 		d.setAlias(cmd, d.StepBefore.Ident)
+	case dash.RenderCubic:
+		err = d.decodeNumberStyle(&d.Cubic)
+		d.setAlias(cmd, d.Cubic.Ident)
+	case dash.RenderMonotone:
+		err = d.decodeNumberStyle(&d.Monotone)
+		d.setAlias(cmd, d.Monotone.Ident)
+	case dash.RenderNatural:
+		err = d.decodeNumberStyle(&d.Natural)
+		d.setAlias(cmd, d.Natural.Ident)
+	case dash.RenderCandle:
+		err = d.decodeNumberStyle(&d.Candle)
+		d.setAlias(cmd, d.Candle.Ident)
+	case dash.RenderOHLC:
+		err = d.decodeNumberStyle(&d.OHLC)
+		d.setAlias(cmd, d.OHLC.Ident)
+	case dash.RenderHistogram:
+		err = d.decodeNumberStyle(&d.Histogram)
+		d.setAlias(cmd, d.Histogram.Ident)
+	case dash.RenderArea:
+		err = d.decodeNumberStyle(&d.Area)
+		d.setAlias(cmd, d.Area.Ident)
+	case dash.RenderAreaStep:
+		err = d.decodeNumberStyle(&d.AreaStep)
+		d.setAlias(cmd, d.AreaStep.Ident)
+	case dash.RenderAreaBefore:
+		err = d.decodeNumberStyle(&d.AreaBefore)
+		d.setAlias(cmd, d.AreaBefore.Ident)
+	case dash.RenderAreaAfter:
+		err = d.decodeNumberStyle(&d.AreaAfter)
+		d.setAlias(cmd, d.AreaAfter.Ident)
+	case dash.RenderAreaStack:
+		err = d.decodeNumberStyle(&d.AreaStack)
+		d.setAlias(cmd, d.AreaStack.Ident)
+	case dash.RenderAreaNorm:
+		err = d.decodeNumberStyle(&d.AreaNorm)
+		d.setAlias(cmd, d.AreaNorm.Ident)
+	case dash.RenderAreaStream:
+		err = d.decodeNumberStyle(&d.AreaStream)
+		d.setAlias(cmd, d.AreaStream.Ident)
+	case dash.RenderScatter:
+		err = d.decodeNumberStyle(&d.Scatter)
+		d.setAlias(cmd, d.Scatter.Ident)
+	case dash.RenderBubble:
+		err = d.decodeNumberStyle(&d.Bubble)
+		d.setAlias(cmd, d.Bubble.Ident)
 	case dash.RenderPie:
 		err = d.decodeCircularStyle(&d.Pie)
 		d.setAlias(cmd, d.Pie.Ident)
@@ -461,6 +630,21 @@ func (d *Decoder) decodeSet(cfg *dash.Config) error {
 	case dash.RenderGroup:
 		err = d.decodeCategoryStyle(&d.Group)
 		d.setAlias(cmd, d.Group.Ident)
+	case dash.RenderBox:
+		err = d.decodeCategoryStyle(&d.Box)
+		d.setAlias(cmd, d.Box.Ident)
+	case dash.RenderViolin:
+		err = d.decodeCategoryStyle(&d.Violin)
+		d.setAlias(cmd, d.Violin.Ident)
+	case dash.RenderHeatmap:
+		err = d.decodeHeatmapStyle(&d.Heatmap)
+		d.setAlias(cmd, d.Heatmap.Ident)
+	case dash.RenderTreemap:
+		err = d.decodeTreemapStyle(&d.Treemap)
+		d.setAlias(cmd, d.Treemap.Ident)
+	case dash.RenderPolar:
+		err = d.decodePolarStyle(&d.Polar)
+		d.setAlias(cmd, d.Polar.Ident)
 	default:
 		err = d.optionError("set")
 	}
@@ -471,6 +655,9 @@ func (d *Decoder) decodeSet(cfg *dash.Config) error {
 }
 
 func (d *Decoder) decodeScaler() (dash.ScalerMaker, error) {
+	if d.is(Literal) && d.curr.Literal == "auto" {
+		return d.decodeAutoScaler()
+	}
 	if !d.peekIs(Keyword) {
 		list, err := d.getStringList()
 		if err != nil {
@@ -478,53 +665,94 @@ func (d *Decoder) decodeScaler() (dash.ScalerMaker, error) {
 		}
 		return dash.ScaleFromList(list), nil
 	}
-	path, err := d.getString()
-	if err != nil {
+	// the domain is now computed from the loaded series: the file and its
+	// columns are only parsed to keep older scripts working.
+	if _, err := d.getString(); err != nil {
 		return nil, err
 	}
 	if err := d.expectKw(kwUsing); err != nil {
 		return nil, err
 	}
 	d.next()
-	var idx dash.Indexer
 	switch d.peek.Type {
 	case Sum:
-		var list []int
-		ix, err := d.getInt()
-		if err != nil {
+		if _, err := d.getInt(); err != nil {
 			return nil, err
 		}
-		list = append(list, ix)
 		for d.curr.Type == Sum {
 			d.next()
-			ix, err := d.getInt()
-			if err != nil {
+			if _, err := d.getInt(); err != nil {
 				return nil, err
 			}
-			list = append(list, ix)
 		}
-		idx = dash.SelectSum(list)
 	case RangeSum:
-		fst, err := d.getInt()
-		if err != nil {
+		if _, err := d.getInt(); err != nil {
 			return nil, err
 		}
 		d.next()
-		lst, err := d.getInt()
-		if err != nil {
+		if _, err := d.getInt(); err != nil {
 			return nil, err
 		}
-		idx = dash.SelectSum(dash.ExpandRange(fst, lst))
 	case EOL, EOF:
-		x, err := d.getInt()
-		if err != nil {
+		if _, err := d.getInt(); err != nil {
 			return nil, err
 		}
-		idx = dash.SelectSingle(x)
 	default:
 		return nil, d.decodeError("expected ':', ':+' or end of line")
 	}
-	return dash.ScaleFromFile(path, idx), nil
+	return dash.ScaleAuto(0, true), nil
+}
+
+func (d *Decoder) decodeAutoScaler() (dash.ScalerMaker, error) {
+	d.next()
+	var (
+		margin float64
+		zero   bool
+	)
+	if err := d.expectKw(kwWith); err != nil {
+		return dash.ScaleAuto(margin, zero), nil
+	}
+	d.next()
+	err := d.decodeWith(func() error {
+		var (
+			cmd = d.curr.Literal
+			err error
+		)
+		d.next()
+		switch cmd {
+		case "margin":
+			margin, err = d.getFloat()
+		case "zero":
+			zero, err = d.getBool()
+		default:
+			err = d.optionError("auto")
+		}
+		if err != nil {
+			return err
+		}
+		return d.eol()
+	})
+	return dash.ScaleAuto(margin, zero), err
+}
+
+func (d *Decoder) decodeScaleType() (dash.ScaleType, error) {
+	var (
+		scale dash.ScaleType
+		err   error
+	)
+	if scale.Kind, err = d.getString(); err != nil {
+		return scale, err
+	}
+	switch scale.Kind {
+	case dash.ScaleLinear, dash.ScaleSqrt:
+	case dash.ScaleLog, dash.ScalePow, dash.ScaleSymlog:
+		if !d.is(EOL) && !d.is(EOF) && !d.is(Comment) {
+			scale.Param, err = d.getFloat()
+		}
+	default:
+		err = fmt.Errorf("%s: unknown scale type provided", scale.Kind)
+	}
+	return scale, err
 }
 
 func (d *Decoder) decodeLegend(cfg *dash.Config) error {
@@ -541,6 +769,8 @@ func (d *Decoder) decodeLegend(cfg *dash.Config) error {
 		if len(cfg.Legend.Position) > 2 && err == nil {
 			err = fmt.Errorf("too many values given for legend position")
 		}
+	case "columns":
+		cfg.Legend.Cols, err = d.getInt()
 	case kwWith:
 		err = d.decodeWith(func() error {
 			return d.decodeLegend(cfg)
@@ -585,6 +815,8 @@ func (d *Decoder) decodeTicks(dom *dash.Domain) error {
 		dom.LabelTicks, err = d.getBool()
 	case "band-ticks":
 		dom.BandTicks, err = d.getBool()
+	case "nice":
+		dom.Nice, err = d.getBool()
 	case kwWith:
 		err = d.decodeWith(func() error {
 			return d.decodeTicks(dom)
@@ -604,14 +836,19 @@ func (d *Decoder) decodeLoadData(cfg *dash.Config) error {
 		err error
 	)
 	dat.Content = d.curr.Literal
+	dat.Delimiter = cfg.Delimiter
 	d.next()
+	list, err := d.decodeTransformList()
+	if err != nil {
+		return err
+	}
 	if err := d.expectKw(kwAs); err != nil {
 		return err
 	}
 	d.next()
 	dat.Ident, err = d.getString()
 	if err == nil {
-		d.files.Define(dat.Ident, dat)
+		d.files.Define(dat.Ident, pipeline(dat, list))
 		err = d.eol()
 	}
 	return err
@@ -627,32 +864,67 @@ func (d *Decoder) decodeLoadExpr(cfg *dash.Config) error {
 		return err
 	}
 	d.next()
+	list, err := d.decodeTransformList()
+	if err != nil {
+		return err
+	}
+	if err = d.expectKw(kwWith); err == nil {
+		if err = d.decodeExprOptions(&expr); err != nil {
+			return err
+		}
+	}
 	if err := d.expectKw(kwAs); err != nil {
 		return err
 	}
 	d.next()
 	expr.Ident, err = d.getString()
 	if err == nil {
-		d.files.Define(expr.Ident, expr)
+		d.files.Define(expr.Ident, pipeline(expr, list))
 		err = d.eol()
 	}
 	return err
 }
 
+func (d *Decoder) decodeExprOptions(expr *dash.Expr) error {
+	d.next()
+	return d.decodeWith(func() error {
+		var (
+			cmd = d.curr.Literal
+			err error
+		)
+		d.next()
+		switch cmd {
+		case "samples":
+			expr.Samples, err = d.getInt()
+		default:
+			err = d.optionError("expr")
+		}
+		if err == nil {
+			err = d.eol()
+		}
+		return err
+	})
+}
+
 func (d *Decoder) decodeLoadExec(cfg *dash.Config) error {
 	var (
 		exec dash.Exec
 		err  error
 	)
 	exec.Command = d.curr.Literal
+	exec.Delimiter = cfg.Delimiter
 	d.next()
+	list, err := d.decodeTransformList()
+	if err != nil {
+		return err
+	}
 	if err := d.expectKw(kwAs); err != nil {
 		return err
 	}
 	d.next()
 	exec.Ident, err = d.getString()
 	if err == nil {
-		d.files.Define(exec.Ident, exec)
+		d.files.Define(exec.Ident, pipeline(exec, list))
 		err = d.eol()
 	}
 	return err
@@ -665,6 +937,7 @@ func (d *Decoder) decodeLoadHttp(cfg *dash.Config, path, ident string) error {
 	)
 	fi.Uri = path
 	fi.Ident = ident
+	fi.Delimiter = cfg.Delimiter
 	fi.Headers = make(http.Header)
 	if err = d.decodeLimit(&fi.Limit); err != nil {
 		return err
@@ -672,6 +945,10 @@ func (d *Decoder) decodeLoadHttp(cfg *dash.Config, path, ident string) error {
 	if err = d.decodeUsing(&fi.Using); err != nil {
 		return err
 	}
+	list, err := d.decodeTransformList()
+	if err != nil {
+		return err
+	}
 	if err = d.expectKw(kwWith); err == nil {
 		err = d.decodeHttpFile(&fi)
 		if err != nil {
@@ -685,7 +962,7 @@ func (d *Decoder) decodeLoadHttp(cfg *dash.Config, path, ident string) error {
 		err = d.eol()
 	}
 	if err == nil {
-		d.files.Define(fi.Ident, fi)
+		d.files.Define(fi.Ident, pipeline(fi, list))
 	}
 	return err
 }
@@ -698,13 +975,19 @@ func (d *Decoder) decodeHttpFile(fi *dash.HttpFile) error {
 			err error
 		)
 		d.next()
+		if ok, err := d.decodeDialect(cmd, &fi.Dialect); ok {
+			if err == nil {
+				err = d.eol()
+			}
+			return err
+		}
 		switch cmd {
 		case "offset":
 			fi.Offset, err = d.getInt()
 		case "count":
 			fi.Count, err = d.getInt()
 		case "xcol":
-			fi.X, err = d.getInt()
+			err = d.decodeColumn(&fi.X, &fi.XName)
 		case "ycol":
 			fi.Y, err = d.decodeSelect()
 		case "query":
@@ -737,12 +1020,17 @@ func (d *Decoder) decodeLoadFile(cfg *dash.Config, path string) error {
 	)
 	fi.Path = path
 	fi.Ident = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	fi.Delimiter = cfg.Delimiter
 	if err = d.decodeLimit(&fi.Limit); err != nil {
 		return err
 	}
 	if err = d.decodeUsing(&fi.Using); err != nil {
 		return err
 	}
+	list, err := d.decodeTransformList()
+	if err != nil {
+		return err
+	}
 	if err = d.expectKw(kwWith); err == nil {
 		err = d.decodeLocalFile(&fi)
 		if err != nil {
@@ -756,7 +1044,7 @@ func (d *Decoder) decodeLoadFile(cfg *dash.Config, path string) error {
 		err = d.eol()
 	}
 	if err == nil {
-		d.files.Define(fi.Name(), fi)
+		d.files.Define(fi.Name(), pipeline(fi, list))
 	}
 	return err
 }
@@ -769,13 +1057,19 @@ func (d *Decoder) decodeLocalFile(fi *dash.LocalFile) error {
 			err error
 		)
 		d.next()
+		if ok, err := d.decodeDialect(cmd, &fi.Dialect); ok {
+			if err == nil {
+				err = d.eol()
+			}
+			return err
+		}
 		switch cmd {
 		case "offset":
 			fi.Offset, err = d.getInt()
 		case "count":
 			fi.Count, err = d.getInt()
 		case "xcol":
-			fi.X, err = d.getInt()
+			err = d.decodeColumn(&fi.X, &fi.XName)
 		case "ycol":
 			fi.Y, err = d.decodeSelect()
 		case "query":
@@ -797,8 +1091,7 @@ func (d *Decoder) decodeUsing(use *dash.Using) error {
 	}
 	d.next()
 	if d.peekIs(Comma) {
-		use.X, err = d.getInt()
-		if err != nil {
+		if err = d.decodeColumn(&use.X, &use.XName); err != nil {
 			return err
 		}
 		d.next()
@@ -807,6 +1100,136 @@ func (d *Decoder) decodeUsing(use *dash.Using) error {
 	return err
 }
 
+// decodeTransform decodes the transforms applied to the points of a source.
+func (d *Decoder) decodeTransform(src dash.DataSource) (dash.DataSource, error) {
+	list, err := d.decodeTransformList()
+	if err != nil {
+		return nil, err
+	}
+	return pipeline(src, list), nil
+}
+
+func (d *Decoder) decodeTransformList() ([]dash.Transform, error) {
+	if err := d.expectKw(kwTransform); err != nil {
+		return nil, nil
+	}
+	d.next()
+	var list []dash.Transform
+	err := d.decodeWith(func() error {
+		var (
+			tf  = dash.Transform{Type: d.curr.Literal}
+			err error
+		)
+		d.next()
+		switch tf.Type {
+		case dash.TransformFilter:
+			if !d.is(Expr) {
+				return d.decodeError("expected expression")
+			}
+			if tf.Expr, err = parse.Parse(strings.NewReader(d.curr.Literal)); err != nil {
+				return err
+			}
+			d.next()
+		case dash.TransformSort:
+			tf.Field = dash.SortX
+			for !d.is(EOL) && !d.is(EOF) && err == nil {
+				var str string
+				if str, err = d.getString(); err != nil {
+					break
+				}
+				switch str {
+				case dash.SortX, dash.SortY:
+					tf.Field = str
+				case "asc", "desc":
+					tf.Desc = str == "desc"
+				default:
+					err = fmt.Errorf("%s: invalid sort order (expected x, y, asc or desc)", str)
+				}
+			}
+		case dash.TransformAggregate:
+			tf.Func, err = d.getAggregate()
+		case dash.TransformRolling:
+			if tf.Func, err = d.getAggregate(); err != nil {
+				break
+			}
+			if tf.Window, err = d.getInt(); err == nil && tf.Window < 1 {
+				err = fmt.Errorf("%d: invalid window (expected number greater than 0)", tf.Window)
+			}
+		case dash.TransformDedupe, dash.TransformCumSum, dash.TransformDiff, dash.TransformChange:
+		default:
+			return d.decodeError(fmt.Sprintf("%s: unsupported transform", tf.Type))
+		}
+		if err == nil {
+			list = append(list, tf)
+			err = d.eol()
+		}
+		return err
+	})
+	return list, err
+}
+
+func (d *Decoder) getAggregate() (string, error) {
+	str, err := d.getString()
+	if err != nil {
+		return str, err
+	}
+	switch str {
+	case dash.AggrSum, dash.AggrMean, dash.AggrMin, dash.AggrMax, dash.AggrCount, dash.AggrMedian:
+	default:
+		return "", fmt.Errorf("%s: invalid function (expected sum, mean, min, max, count or median)", str)
+	}
+	return str, nil
+}
+
+func pipeline(src dash.DataSource, list []dash.Transform) dash.DataSource {
+	if len(list) == 0 {
+		return src
+	}
+	return dash.Pipeline{
+		Source:     src,
+		Transforms: list,
+	}
+}
+
+func (d *Decoder) decodeDialect(cmd string, dialect *dash.Dialect) (bool, error) {
+	var err error
+	switch cmd {
+	default:
+		return false, nil
+	case "delimiter":
+		var str string
+		if str, err = d.getString(); err == nil {
+			dialect.Delimiter = dash.GetDelimiter(str)
+		}
+	case "header":
+		var str string
+		if str, err = d.getString(); err == nil {
+			dialect.Header, err = dash.GetHeaderMode(str)
+		}
+	case "comment":
+		dialect.Comment, err = d.getString()
+	case "lazy-quotes":
+		dialect.LazyQuotes, err = d.getBool()
+	case "skip":
+		dialect.Skip, err = d.getInt()
+	}
+	return true, err
+}
+
+// decodeColumn decodes a column given by its index or by its name.
+func (d *Decoder) decodeColumn(index *int, name *string) error {
+	str, err := d.getString()
+	if err != nil {
+		return err
+	}
+	if x, err := strconv.Atoi(str); err == nil {
+		*index, *name = x, ""
+	} else {
+		*index, *name = 0, str
+	}
+	return nil
+}
+
 func (d *Decoder) decodeLimit(lim *dash.Limit) error {
 	err := d.expectKw(kwLimit)
 	if err != nil {
@@ -856,65 +1279,73 @@ func (d *Decoder) decodeLoad(cfg *dash.Config) error {
 }
 
 func (d *Decoder) decodeSelect() (dash.Selector, error) {
-	getRange := func() ([]int, error) {
-		fst, err := d.getInt()
+	getRange := func() ([]string, error) {
+		fst, err := d.getString()
 		if err != nil {
 			return nil, err
 		}
 		d.next()
-		lst, err := d.getInt()
+		lst, err := d.getString()
 		if err != nil {
 			return nil, err
 		}
-		return dash.ExpandRange(fst, lst), nil
+		return []string{fst, lst}, nil
 	}
-	getList := func(want rune) ([]int, error) {
-		var list []int
-		i, err := d.getInt()
+	getList := func(want rune) ([]string, error) {
+		var list []string
+		str, err := d.getString()
 		if err != nil {
 			return nil, err
 		}
-		list = append(list, i)
+		list = append(list, str)
 		for d.curr.Type == want {
 			d.next()
-			i, err := d.getInt()
+			str, err := d.getString()
 			if err != nil {
 				return nil, err
 			}
-			list = append(list, i)
+			list = append(list, str)
 		}
 		return list, nil
 	}
+	expand := func(list []int) []int {
+		return dash.ExpandRange(list[0], list[1])
+	}
 	var xs []dash.Selector
 	for !d.is(EOL) && !d.is(EOF) && !d.is(Keyword) {
+		var (
+			cols   []string
+			create func([]int) dash.Selector
+			err    error
+		)
 		switch d.peek.Type {
 		case Comma, Keyword, EOL, EOF:
-			i, err := d.getInt()
-			if err != nil {
-				return nil, err
+			var str string
+			str, err = d.getString()
+			cols = append(cols, str)
+			create = func(list []int) dash.Selector {
+				return dash.SelectSingle(slices.Fst(list))
 			}
-			xs = append(xs, dash.SelectSingle(i))
 		case Sum:
-			rg, err := getList(Sum)
-			if err != nil {
-				return nil, err
-			}
-			xs = append(xs, dash.SelectSum(rg))
+			cols, err = getList(Sum)
+			create = dash.SelectSum
 		case Range:
-			rg, err := getRange()
-			if err != nil {
-				return nil, err
+			cols, err = getRange()
+			create = func(list []int) dash.Selector {
+				return dash.SelectMulti(expand(list))
 			}
-			xs = append(xs, dash.SelectMulti(rg))
 		case RangeSum:
-			rg, err := getRange()
-			if err != nil {
-				return nil, err
+			cols, err = getRange()
+			create = func(list []int) dash.Selector {
+				return dash.SelectSum(expand(list))
 			}
-			xs = append(xs, dash.SelectSum(rg))
 		default:
 			return nil, d.decodeError("expected ',', ':', ':+', keyword or end of line")
 		}
+		if err != nil {
+			return nil, err
+		}
+		xs = append(xs, selectColumns(cols, create))
 		switch d.curr.Type {
 		case Comma:
 			d.next()
@@ -929,6 +1360,20 @@ func (d *Decoder) decodeSelect() (dash.Selector, error) {
 	return dash.Combined(xs...), nil
 }
 
+// selectColumns gives the selector of the columns given by their index.
+// Columns given by their name are resolved later against the header.
+func selectColumns(cols []string, create func([]int) dash.Selector) dash.Selector {
+	list := make([]int, len(cols))
+	for i := range cols {
+		x, err := strconv.Atoi(cols[i])
+		if err != nil {
+			return dash.SelectNamed(cols, create)
+		}
+		list[i] = x
+	}
+	return create(list)
+}
+
 func (d *Decoder) decodeWith(decode func() error) error {
 	if err := d.expect(Lparen, "expected '('"); err != nil {
 		return err
@@ -988,6 +1433,41 @@ func (d *Decoder) decodeGlobalStyle(cmd string, style *dash.Style) (bool, error)
 	return true, err
 }
 
+func (d *Decoder) decodeErrorStyle(cmd string, style *charts.ErrorStyle) (bool, error) {
+	var err error
+	switch cmd {
+	default:
+		return false, nil
+	case "error":
+		style.Visible, err = d.getBool()
+	case "error-color":
+		style.Color, err = d.getString()
+	case "error-opacity":
+		style.Opacity, err = d.getFloat()
+	case "error-width":
+		style.Width, err = d.getFloat()
+	case "error-cap":
+		style.Cap, err = d.getFloat()
+	}
+	return true, err
+}
+
+func (d *Decoder) decodeLabels(cmd string, labels *charts.Labels, format *string) (bool, error) {
+	var err error
+	switch cmd {
+	default:
+		return false, nil
+	case "labels":
+		var str string
+		if str, err = d.getString(); err == nil {
+			labels.Position = dash.GetLabelPosition(str)
+		}
+	case "label-format":
+		*format, err = d.getString()
+	}
+	return true, err
+}
+
 func (d *Decoder) decodeNumberStyle(style *dash.NumberStyle) error {
 	var (
 		cmd = d.curr.Literal
@@ -998,6 +1478,12 @@ func (d *Decoder) decodeNumberStyle(style *dash.NumberStyle) error {
 	if ok {
 		return err
 	}
+	if ok, err = d.decodeErrorStyle(cmd, &style.Error); ok {
+		return err
+	}
+	if ok, err = d.decodeLabels(cmd, &style.Labels, &style.LabelFormat); ok {
+		return err
+	}
 	switch cmd {
 	case "text-position":
 		var line string
@@ -1008,6 +1494,39 @@ func (d *Decoder) decodeNumberStyle(style *dash.NumberStyle) error {
 		style.TextPosition = dash.GetTextPosition(line)
 	case "ignore-missing":
 		style.IgnoreMissing, err = d.getBool()
+	case "tension":
+		style.Tension, err = d.getFloat()
+	case "baseline":
+		style.Baseline, err = d.getFloat()
+	case "width":
+		style.Width, err = d.getFloat()
+	case "bins":
+		var str string
+		if str, err = d.getString(); err != nil {
+			break
+		}
+		if n, err1 := strconv.Atoi(str); err1 == nil {
+			style.Binning.Strategy = charts.BinCount
+			style.Binning.Count = n
+		} else {
+			style.Binning.Strategy, err = dash.GetBinStrategy(str)
+		}
+	case "bin-width":
+		style.Binning.Strategy = charts.BinWidth
+		style.Binning.Width, err = d.getFloat()
+	case "bin-mode":
+		var str string
+		if str, err = d.getString(); err == nil {
+			style.Binning.Mode = dash.GetBinMode(str)
+		}
+	case "point":
+		style.Point, err = d.getString()
+	case "size":
+		style.Size, err = d.getFloat()
+	case "scheme":
+		style.Scheme, err = d.getString()
+	case "axis":
+		style.Axis, err = d.getAxis()
 	case kwWith:
 		err = d.decodeWith(func() error {
 			err := d.decodeNumberStyle(style)
@@ -1043,9 +1562,17 @@ func (d *Decoder) decodeCategoryStyle(style *dash.CategoryStyle) error {
 	if ok {
 		return err
 	}
+	if ok, err = d.decodeErrorStyle(cmd, &style.Error); ok {
+		return err
+	}
+	if ok, err = d.decodeLabels(cmd, &style.Labels, &style.LabelFormat); ok {
+		return err
+	}
 	switch cmd {
 	case "width":
 		style.Width, err = d.getFloat()
+	case "axis":
+		style.Axis, err = d.getAxis()
 	case kwWith:
 		err = d.decodeWith(func() error {
 			err := d.decodeCategoryStyle(style)
@@ -1081,6 +1608,9 @@ func (d *Decoder) decodeCircularStyle(style *dash.CircularStyle) error {
 	if ok {
 		return err
 	}
+	if ok, err = d.decodeLabels(cmd, &style.Labels, &style.LabelFormat); ok {
+		return err
+	}
 	switch cmd {
 	case "fill":
 		style.Fill, err = d.getStringList()
@@ -1113,6 +1643,143 @@ func (d *Decoder) decodeCircularStyle(style *dash.CircularStyle) error {
 	return err
 }
 
+func (d *Decoder) decodeHeatmapStyle(style *dash.HeatmapStyle) error {
+	var (
+		cmd = d.curr.Literal
+		err error
+	)
+	d.next()
+	ok, err := d.decodeGlobalStyle(cmd, &style.Style)
+	if ok {
+		return err
+	}
+	switch cmd {
+	case "scheme":
+		style.Scheme, err = d.getString()
+	case "interpolate":
+		style.Interpolate, err = d.getString()
+	case "midpoint":
+		style.Diverging = true
+		style.Midpoint, err = d.getFloat()
+	case kwWith:
+		err = d.decodeWith(func() error {
+			err := d.decodeHeatmapStyle(style)
+			if err == nil {
+				err = d.eol()
+			}
+			return err
+		})
+		if err != nil {
+			break
+		}
+		if tmp := d.expectKw(kwAs); tmp == nil {
+			d.next()
+			alias, err := d.getString()
+			if err == nil {
+				d.styles.Define(alias, *style)
+				style.Ident = alias
+			}
+		}
+	default:
+		err = d.optionError("heatmap-style")
+	}
+	return err
+}
+
+func (d *Decoder) decodeTreemapStyle(style *dash.TreemapStyle) error {
+	var (
+		cmd = d.curr.Literal
+		err error
+	)
+	d.next()
+	ok, err := d.decodeGlobalStyle(cmd, &style.Style)
+	if ok {
+		return err
+	}
+	switch cmd {
+	case "layout":
+		style.Layout, err = d.getString()
+	case "padding":
+		var list []float64
+		if list, err = d.getFloatList(); err != nil {
+			break
+		}
+		style.Padding, err = charts.PaddingFromList(list)
+	case kwWith:
+		err = d.decodeWith(func() error {
+			err := d.decodeTreemapStyle(style)
+			if err == nil {
+				err = d.eol()
+			}
+			return err
+		})
+		if err != nil {
+			break
+		}
+		if tmp := d.expectKw(kwAs); tmp == nil {
+			d.next()
+			alias, err := d.getString()
+			if err == nil {
+				d.styles.Define(alias, *style)
+				style.Ident = alias
+			}
+		}
+	default:
+		err = d.optionError("treemap-style")
+	}
+	return err
+}
+
+func (d *Decoder) decodePolarStyle(style *dash.PolarStyle) error {
+	var (
+		cmd = d.curr.Literal
+		err error
+	)
+	d.next()
+	ok, err := d.decodeGlobalStyle(cmd, &style.Style)
+	if ok {
+		return err
+	}
+	switch cmd {
+	case "mode":
+		style.Mode, err = d.getString()
+	case "radius":
+		style.Radius, err = d.getFloat()
+	case "ticks":
+		style.Ticks, err = d.getInt()
+	case "ticks-type":
+		style.TicksStyle, err = d.getString()
+	case "angular":
+		style.Angular, err = d.getBool()
+	case "stacked":
+		style.Stacked, err = d.getBool()
+	case "point":
+		style.Point, err = d.getString()
+	case kwWith:
+		err = d.decodeWith(func() error {
+			err := d.decodePolarStyle(style)
+			if err == nil {
+				err = d.eol()
+			}
+			return err
+		})
+		if err != nil {
+			break
+		}
+		if tmp := d.expectKw(kwAs); tmp == nil {
+			d.next()
+			alias, err := d.getString()
+			if err == nil {
+				d.styles.Define(alias, *style)
+				style.Ident = alias
+			}
+		}
+	default:
+		err = d.optionError("polar-style")
+	}
+	return err
+}
+
 func (d *Decoder) decodeElementStyle(el *dash.Element) error {
 	var err error
 	switch style := el.Style.(type) {
@@ -1127,6 +1794,15 @@ func (d *Decoder) decodeElementStyle(el *dash.Element) error {
 	case dash.CircularStyle:
 		err = d.decodeCircularStyle(&style)
 		el.Style = style
+	case dash.HeatmapStyle:
+		err = d.decodeHeatmapStyle(&style)
+		el.Style = style
+	case dash.TreemapStyle:
+		err = d.decodeTreemapStyle(&style)
+		el.Style = style
+	case dash.PolarStyle:
+		err = d.decodePolarStyle(&style)
+		el.Style = style
 	}
 	return err
 }
@@ -1148,6 +1824,36 @@ func (d *Decoder) getStyleForType(kind string, cfg *dash.Config) (any, error) {
 		style = d.StepAfter
 	case dash.RenderStepBefore:
 		style = d.StepBefore
+	case dash.RenderCubic:
+		style = d.Cubic
+	case dash.RenderMonotone:
+		style = d.Monotone
+	case dash.RenderNatural:
+		style = d.Natural
+	case dash.RenderCandle:
+		style = d.Candle
+	case dash.RenderOHLC:
+		style = d.OHLC
+	case dash.RenderHistogram:
+		style = d.Histogram
+	case dash.RenderArea:
+		style = d.Area
+	case dash.RenderAreaStep:
+		style = d.AreaStep
+	case dash.RenderAreaBefore:
+		style = d.AreaBefore
+	case dash.RenderAreaAfter:
+		style = d.AreaAfter
+	case dash.RenderAreaStack:
+		style = d.AreaStack
+	case dash.RenderAreaNorm:
+		style = d.AreaNorm
+	case dash.RenderAreaStream:
+		style = d.AreaStream
+	case dash.RenderScatter:
+		style = d.Scatter
+	case dash.RenderBubble:
+		style = d.Bubble
 	case dash.RenderPie:
 		style = d.Pie.Copy()
 	case dash.RenderBar:
@@ -1160,6 +1866,16 @@ func (d *Decoder) getStyleForType(kind string, cfg *dash.Config) (any, error) {
 		style = d.NormStack.Copy()
 	case dash.RenderGroup:
 		style = d.Group.Copy()
+	case dash.RenderBox:
+		style = d.Box.Copy()
+	case dash.RenderViolin:
+		style = d.Violin.Copy()
+	case dash.RenderHeatmap:
+		style = d.Heatmap
+	case dash.RenderTreemap:
+		style = d.Treemap.Copy()
+	case dash.RenderPolar:
+		style = d.Polar.Copy()
 	}
 	return style, nil
 }
@@ -1259,7 +1975,14 @@ func (d *Decoder) getRenderType() (string, error) {
 	}
 	switch str {
 	case dash.RenderLine, dash.RenderStep, dash.RenderStepAfter, dash.RenderStepBefore:
+	case dash.RenderCubic, dash.RenderMonotone, dash.RenderNatural:
+	case dash.RenderCandle, dash.RenderOHLC, dash.RenderHistogram:
+	case dash.RenderArea, dash.RenderAreaStep, dash.RenderAreaBefore, dash.RenderAreaAfter:
+	case dash.RenderAreaStack, dash.RenderAreaNorm, dash.RenderAreaStream:
+	case dash.RenderScatter, dash.RenderBubble:
 	case dash.RenderBar, dash.RenderPie, dash.RenderStack, dash.RenderNormStack, dash.RenderGroup:
+	case dash.RenderBox, dash.RenderViolin, dash.RenderHeatmap, dash.RenderTreemap:
+	case dash.RenderPolar:
 	default:
 		return "", fmt.Errorf("%s: unknown renderer type provided", str)
 	}
@@ -1309,6 +2032,19 @@ func (d *Decoder) getString() (string, error) {
 	return str, nil
 }
 
+func (d *Decoder) getAxis() (string, error) {
+	str, err := d.getString()
+	if err != nil {
+		return str, err
+	}
+	switch str {
+	case dash.PosLeft, dash.PosRight:
+	default:
+		return "", fmt.Errorf("%s: invalid axis (expected left or right)", str)
+	}
+	return str, nil
+}
+
 func (d *Decoder) getBool() (bool, error) {
 	str, err := d.getString()
 	if err != nil {
@@ -2,7 +2,10 @@ package decode
 
 import (
 	"os"
+	"reflect"
 	"testing"
+
+	"github.com/midbel/charts/dash"
 )
 
 func TestDecoder_Decode(t *testing.T) {
@@ -17,3 +20,41 @@ func TestDecoder_Decode(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestSelectColumns(t *testing.T) {
+	data := []struct {
+		Cols  []string
+		Want  []float64
+		Named bool
+	}{
+		{
+			Cols: []string{"1", "3"},
+			Want: []float64{2, 4},
+		},
+		{
+			Cols:  []string{"Open", "Close"},
+			Named: true,
+		},
+		{
+			Cols:  []string{"1", "Close"},
+			Named: true,
+		},
+	}
+	row := []string{"2022-09-01", "2", "3", "4"}
+	for _, d := range data {
+		got, err := selectColumns(d.Cols, dash.SelectMulti).Select(row)
+		if d.Named {
+			if err == nil {
+				t.Errorf("%q: names should be resolved before selecting", d.Cols)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", d.Cols, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, d.Want) {
+			t.Errorf("%q: values mismatched: want %v, got %v", d.Cols, d.Want, got)
+		}
+	}
+}
@@ -5,19 +5,21 @@ import (
 )
 
 const (
-	kwSet     = "set"
-	kwLoad    = "load"
-	kwUsing   = "using"
-	kwRender  = "render"
-	kwWith    = "with"
-	kwLimit   = "limit"
-	kwInclude = "include"
-	kwDefine  = "define"
-	kwDeclare = "declare"
-	kwAt      = "at"
-	kwUse     = "use"
-	kwTo      = "to"
-	kwAs      = "as"
+	kwSet       = "set"
+	kwLoad      = "load"
+	kwUsing     = "using"
+	kwRender    = "render"
+	kwWith      = "with"
+	kwLimit     = "limit"
+	kwInclude   = "include"
+	kwDefine    = "define"
+	kwDeclare   = "declare"
+	kwAt        = "at"
+	kwUse       = "use"
+	kwTo        = "to"
+	kwAs        = "as"
+	kwAnnotate  = "annotate"
+	kwTransform = "transform"
 )
 
 func isKeyword(str string) bool {
@@ -37,6 +39,8 @@ func isKeyword(str string) bool {
 	case kwUse:
 	case kwAs:
 	case kwTo:
+	case kwAnnotate:
+	case kwTransform:
 	}
 	return true
 }
@@ -45,9 +45,14 @@ func main() {
 
 func getRenderer() charts.Renderer[string, float64] {
 	return charts.PolarRenderer[string, float64]{
+		Style: charts.Style{
+			FillList:    charts.Tableau10,
+			FillOpacity: 0.7,
+			LineWidth:   1,
+			LineOpacity: 1,
+		},
 		Ticks:      10,
 		Radius:     220,
-		Fill:       charts.Tableau10,
 		TicksStyle: charts.StyleDashed,
 		Type:       charts.PolarArea,
 		Stacked:    true,
@@ -0,0 +1,109 @@
+package charts
+
+import (
+	"math"
+	"sort"
+
+	"github.com/midbel/svg"
+)
+
+type LabelPosition int
+
+const (
+	LabelNone LabelPosition = iota
+	LabelInside
+	LabelOutside
+	LabelEnd
+)
+
+// Labels writes the values of the points on the elements drawn for them. The
+// values are written with strconv when Format is not set.
+type Labels struct {
+	Position LabelPosition
+	Format   func(float64) string
+}
+
+func (l Labels) Visible() bool {
+	return l.Position != LabelNone
+}
+
+func (l Labels) format(v float64) string {
+	if l.Format != nil {
+		return l.Format(v)
+	}
+	return formatValue(v)
+}
+
+type label struct {
+	Text   string
+	Pos    svg.Pos
+	Anchor string
+	Hidden bool
+}
+
+func (l label) box(size float64) (x1, y1, x2, y2 float64) {
+	width := textWidth(l.Text, size)
+	switch l.Anchor {
+	case "start":
+		x1 = l.Pos.X
+	case "end":
+		x1 = l.Pos.X - width
+	default:
+		x1 = l.Pos.X - width/2
+	}
+	y1 = l.Pos.Y - size/2
+	return x1, y1, x1 + width, y1 + size
+}
+
+// spreadLabels moves down the labels overlapping the ones placed before them,
+// from top to bottom. Labels that would have to move more than limit are
+// hidden.
+func spreadLabels(list []label, size, limit float64) {
+	order := make([]int, len(list))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return list[order[i]].Pos.Y < list[order[j]].Pos.Y
+	})
+	var placed []int
+	for _, i := range order {
+		var (
+			orig  = list[i].Pos.Y
+			moved = true
+		)
+		for moved && !list[i].Hidden {
+			moved = false
+			x1, y1, x2, y2 := list[i].box(size)
+			for _, j := range placed {
+				a1, b1, a2, b2 := list[j].box(size)
+				if x1 >= a2 || a1 >= x2 || y1 >= b2 || b1 >= y2 {
+					continue
+				}
+				list[i].Pos.Y += b2 - y1
+				moved = true
+				break
+			}
+			if math.Abs(list[i].Pos.Y-orig) > limit {
+				list[i].Hidden = true
+			}
+		}
+		if !list[i].Hidden {
+			placed = append(placed, i)
+		}
+	}
+}
+
+func (s Style) drawLabels(list []label) svg.Element {
+	grp := classGroup("labels")
+	for _, l := range list {
+		if l.Hidden {
+			continue
+		}
+		txt := s.Text(l.Text)
+		txt.Pos = l.Pos
+		txt.Anchor = l.Anchor
+		grp.Append(txt.AsElement())
+	}
+	return grp.AsElement()
+}
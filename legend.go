@@ -0,0 +1,186 @@
+package charts
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/midbel/svg"
+)
+
+var (
+	LegendWidth  = 160.0
+	LegendMargin = FontSize * 0.5
+)
+
+type Swatch struct {
+	Title     string
+	LineType  LineStyle
+	LineColor string
+	LineWidth float64
+	Fill      string
+	Opacity   float64
+	Point     PointFunc
+}
+
+func (s Swatch) Render(width, height float64) svg.Element {
+	grp := classGroup("legend-swatch")
+	if s.hasFill() {
+		var rec svg.Rect
+		rec.Dim = svg.NewDim(width, height)
+		rec.Fill = svg.NewFill(s.Fill)
+		rec.Fill.Opacity = s.Opacity
+		if s.LineColor != "" && s.LineColor != ColorNone {
+			rec.Stroke = svg.NewStroke(s.LineColor, 1)
+		}
+		grp.Append(rec.AsElement())
+	} else if s.LineColor != "" && s.LineColor != ColorNone {
+		li := svg.NewLine(svg.NewPos(0, height/2), svg.NewPos(width, height/2))
+		li.Stroke = svg.NewStroke(s.LineColor, math.Max(s.LineWidth, 2))
+		switch s.LineType {
+		case StyleDotted:
+			li.Stroke.DashArray = append(li.Stroke.DashArray, 1, 5)
+		case StyleDashed:
+			li.Stroke.DashArray = append(li.Stroke.DashArray, 10, 5)
+		default:
+		}
+		grp.Append(li.AsElement())
+	}
+	if s.Point != nil {
+		grp.Append(s.Point(svg.NewPos(width/2, height/2), DefaultSize, s.color()))
+	}
+	return grp.AsElement()
+}
+
+func (s Swatch) hasFill() bool {
+	return s.Fill != "" && s.Fill != ColorNone
+}
+
+func (s Swatch) color() string {
+	if s.hasFill() {
+		return s.Fill
+	}
+	if s.LineColor != "" {
+		return s.LineColor
+	}
+	return ColorBlack
+}
+
+type legendEntry struct {
+	Swatch
+	ident string
+	lines []string
+	width float64
+}
+
+func (e legendEntry) height(line float64) float64 {
+	return float64(len(e.lines)) * line
+}
+
+func createLegendEntries(series []Data, chars int) []legendEntry {
+	var list []legendEntry
+	for _, s := range series {
+		sw := s.Swatch()
+		if sw.Title == "" {
+			continue
+		}
+		e := legendEntry{
+			Swatch: sw,
+			ident:  s.Id(),
+			lines:  wrapText(sw.Title, chars),
+		}
+		for _, str := range e.lines {
+			e.width = math.Max(e.width, textWidth(str, FontSize))
+		}
+		list = append(list, e)
+	}
+	return list
+}
+
+func splitLegendColumns(entries []legendEntry, cols int) [][]legendEntry {
+	if cols <= 0 {
+		cols = 1
+	}
+	if cols > len(entries) {
+		cols = len(entries)
+	}
+	var (
+		list = make([][]legendEntry, 0, cols)
+		rows = int(math.Ceil(float64(len(entries)) / float64(cols)))
+	)
+	for i := 0; i < len(entries); i += rows {
+		j := i + rows
+		if j > len(entries) {
+			j = len(entries)
+		}
+		list = append(list, entries[i:j])
+	}
+	return list
+}
+
+func columnHeight(entries []legendEntry, line float64) float64 {
+	var height float64
+	for _, e := range entries {
+		height += e.height(line)
+	}
+	return height
+}
+
+func columnWidth(entries []legendEntry) float64 {
+	var width float64
+	for _, e := range entries {
+		width = math.Max(width, e.width)
+	}
+	return width
+}
+
+func multilineText(lines []string, pos svg.Pos, offset float64, font svg.Font) svg.Text {
+	if len(lines) <= 1 {
+		txt := newText(strings.Join(lines, ""))
+		txt.Pos = pos
+		txt.Font = font
+		return txt
+	}
+	var txt svg.Text
+	txt.Pos = pos
+	txt.Font = font
+	for i, str := range lines {
+		span := svg.TextSpan{
+			Literal: xmlEscaper.Replace(str),
+			Pos:     pos,
+			Shift:   svg.NewPos(0, float64(i)*offset),
+		}
+		txt.Append(span.AsElement())
+	}
+	return txt
+}
+
+func wrapText(str string, chars int) []string {
+	words := strings.Fields(str)
+	if chars <= 0 || len(words) == 0 {
+		return []string{str}
+	}
+	var (
+		list []string
+		line strings.Builder
+	)
+	for _, w := range words {
+		n := utf8.RuneCountInString(line.String())
+		if n > 0 && n+1+utf8.RuneCountInString(w) > chars {
+			list = append(list, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteString(" ")
+		}
+		line.WriteString(w)
+	}
+	if line.Len() > 0 {
+		list = append(list, line.String())
+	}
+	return list
+}
+
+func textWidth(str string, size float64) float64 {
+	return float64(utf8.RuneCountInString(str)) * size * 0.6
+}
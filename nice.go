@@ -0,0 +1,312 @@
+package charts
+
+import (
+	"math"
+	"time"
+)
+
+const defaultNiceCount = 10
+
+type scalerNice[T ScalerConstraint] interface {
+	nice(int) Scaler[T]
+	niceValues(int) []T
+}
+
+// NiceScaler widens the domain of the given scaler so that its bounds fall
+// on round values. Scalers that do not support it are returned unchanged.
+func NiceScaler[T ScalerConstraint](s Scaler[T], count int) Scaler[T] {
+	n, ok := s.(scalerNice[T])
+	if !ok {
+		return s
+	}
+	return n.nice(count)
+}
+
+func niceValues[T ScalerConstraint](s Scaler[T], count int) []T {
+	n, ok := s.(scalerNice[T])
+	if !ok {
+		return s.Values(count)
+	}
+	return n.niceValues(count)
+}
+
+func (n numberScaler) nice(count int) Scaler[float64] {
+	var (
+		x        = n
+		fst, lst = domainBounds(n.Domain)
+	)
+	x.Domain = niceNumberDomain(fst, lst, count)
+	return x
+}
+
+func (n numberScaler) niceValues(count int) []float64 {
+	fst, lst := domainBounds(n.Domain)
+	return niceNumberTicks(fst, lst, count)
+}
+
+func (s powScaler) nice(count int) Scaler[float64] {
+	x := s
+	x.Domain = niceNumberDomain(s.fst, s.lst, count)
+	x.fst, x.lst = domainBounds(x.Domain)
+	return x
+}
+
+func (s powScaler) niceValues(count int) []float64 {
+	return niceNumberTicks(s.fst, s.lst, count)
+}
+
+func (s logScaler) nice(count int) Scaler[float64] {
+	var (
+		x      = s
+		lo, hi = orderBounds(s.fst, s.lst)
+	)
+	lo = math.Pow(s.base, math.Floor(s.log(lo)))
+	hi = math.Pow(s.base, math.Ceil(s.log(hi)))
+	if s.fst > s.lst {
+		lo, hi = hi, lo
+	}
+	x.fst, x.lst = lo, hi
+	return x
+}
+
+func (s logScaler) niceValues(count int) []float64 {
+	return s.Values(count)
+}
+
+func (s timeScaler) nice(count int) Scaler[time.Time] {
+	var (
+		x        = s
+		fst, lst = timeBounds(s.Domain)
+	)
+	x.Domain = niceTimeDomain(fst, lst, count)
+	return x
+}
+
+func (s timeScaler) niceValues(count int) []time.Time {
+	fst, lst := timeBounds(s.Domain)
+	return niceTimeTicks(fst, lst, count)
+}
+
+// niceStep returns a step of 1, 2 or 5 times a power of ten such that the
+// given extent is divided in approximatively count intervals.
+func niceStep(extent float64, count int) float64 {
+	if count <= 0 {
+		count = defaultNiceCount
+	}
+	var (
+		raw  = math.Abs(extent) / float64(count)
+		pow  = math.Pow(10, math.Floor(math.Log10(raw)))
+		frac = raw / pow
+	)
+	switch {
+	case frac >= 7.5:
+		return 10 * pow
+	case frac >= 3.5:
+		return 5 * pow
+	case frac >= 1.5:
+		return 2 * pow
+	default:
+		return pow
+	}
+}
+
+func NiceNumberDomain(f, t float64, count int) Domain[float64] {
+	return niceNumberDomain(f, t, count)
+}
+
+func niceNumberDomain(f, t float64, count int) numberDomain {
+	lo, hi := orderBounds(f, t)
+	if lo == hi {
+		return numberDomain{fst: f, lst: t}
+	}
+	for i := 0; i < 2; i++ {
+		step := niceStep(hi-lo, count)
+		lo = math.Floor(lo/step) * step
+		hi = math.Ceil(hi/step) * step
+	}
+	if f > t {
+		lo, hi = hi, lo
+	}
+	return numberDomain{
+		fst: lo,
+		lst: hi,
+	}
+}
+
+func niceNumberTicks(f, t float64, count int) []float64 {
+	lo, hi := orderBounds(f, t)
+	if lo == hi {
+		return []float64{f}
+	}
+	var (
+		step = niceStep(hi-lo, count)
+		beg  = math.Ceil(lo / step)
+		end  = math.Floor(hi / step)
+		list []float64
+	)
+	for i := beg; i <= end; i++ {
+		list = append(list, cleanFloat(i*step, step))
+	}
+	return orderValues(list, f > t)
+}
+
+// cleanFloat removes the rounding errors introduced by multiplying the step
+// by an integer (eg: 3 * 0.1 gives 0.30000000000000004).
+func cleanFloat(v, step float64) float64 {
+	prec := math.Max(0, -math.Floor(math.Log10(step))) + 1
+	pow := math.Pow(10, prec)
+	return math.Round(v*pow) / pow
+}
+
+type timeInterval struct {
+	unit  time.Duration
+	step  int
+	month int
+	year  int
+}
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+var timeIntervals = []timeInterval{
+	{unit: time.Second, step: 1},
+	{unit: time.Second, step: 5},
+	{unit: time.Second, step: 15},
+	{unit: time.Second, step: 30},
+	{unit: time.Minute, step: 1},
+	{unit: time.Minute, step: 5},
+	{unit: time.Minute, step: 15},
+	{unit: time.Minute, step: 30},
+	{unit: time.Hour, step: 1},
+	{unit: time.Hour, step: 3},
+	{unit: time.Hour, step: 6},
+	{unit: time.Hour, step: 12},
+	{unit: day, step: 1},
+	{unit: day, step: 2},
+	{unit: week, step: 1},
+	{month: 1},
+	{month: 3},
+	{year: 1},
+}
+
+func (i timeInterval) approx() float64 {
+	switch {
+	case i.year > 0:
+		return float64(i.year) * 365 * float64(day)
+	case i.month > 0:
+		return float64(i.month) * 30 * float64(day)
+	default:
+		return float64(i.unit) * float64(i.step)
+	}
+}
+
+func (i timeInterval) floor(t time.Time) time.Time {
+	var (
+		year, month, dom = t.Date()
+		loc              = t.Location()
+	)
+	switch {
+	case i.year > 0:
+		year -= year % i.year
+		return time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	case i.month > 0:
+		month -= (month - 1) % time.Month(i.month)
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	case i.unit == week:
+		t = time.Date(year, month, dom, 0, 0, 0, 0, loc)
+		diff := (int(t.Weekday()) + 6) % 7
+		return t.AddDate(0, 0, -diff)
+	case i.unit == day:
+		dom -= (dom - 1) % i.step
+		return time.Date(year, month, dom, 0, 0, 0, 0, loc)
+	default:
+		return t.Truncate(i.unit * time.Duration(i.step))
+	}
+}
+
+func (i timeInterval) ceil(t time.Time) time.Time {
+	f := i.floor(t)
+	if f.Equal(t) {
+		return f
+	}
+	return i.next(f)
+}
+
+func (i timeInterval) next(t time.Time) time.Time {
+	switch {
+	case i.year > 0:
+		return t.AddDate(i.year, 0, 0)
+	case i.month > 0:
+		return t.AddDate(0, i.month, 0)
+	case i.unit == week:
+		return t.AddDate(0, 0, 7*i.step)
+	case i.unit == day:
+		return t.AddDate(0, 0, i.step)
+	default:
+		return t.Add(i.unit * time.Duration(i.step))
+	}
+}
+
+func selectInterval(extent time.Duration, count int) timeInterval {
+	if count <= 0 {
+		count = defaultNiceCount
+	}
+	var (
+		target = math.Abs(float64(extent)) / float64(count)
+		best   = timeIntervals[0]
+	)
+	for _, i := range timeIntervals {
+		if math.Abs(math.Log(i.approx()/target)) < math.Abs(math.Log(best.approx()/target)) {
+			best = i
+		}
+	}
+	if best.year > 0 {
+		years := niceStep(target*float64(count)/best.approx(), count)
+		best.year = int(math.Max(1, years))
+	}
+	return best
+}
+
+func NiceTimeDomain(f, t time.Time, count int) Domain[time.Time] {
+	return niceTimeDomain(f, t, count)
+}
+
+func niceTimeDomain(f, t time.Time, count int) timeDomain {
+	reverse := f.After(t)
+	if reverse {
+		f, t = t, f
+	}
+	var (
+		ival = selectInterval(t.Sub(f), count)
+		dom  = timeDomain{
+			fst: ival.floor(f),
+			lst: ival.ceil(t),
+		}
+	)
+	if reverse {
+		dom.fst, dom.lst = dom.lst, dom.fst
+	}
+	return dom
+}
+
+func niceTimeTicks(f, t time.Time, count int) []time.Time {
+	reverse := f.After(t)
+	if reverse {
+		f, t = t, f
+	}
+	var (
+		ival = selectInterval(t.Sub(f), count)
+		list []time.Time
+	)
+	for w := ival.ceil(f); !w.After(t); w = ival.next(w) {
+		list = append(list, w)
+	}
+	if reverse {
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+	}
+	return list
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/charts"
+)
+
+// parseColor returns the color and true if the given value designates a
+// visible paint.
+func parseColor(str string) (color.NRGBA, bool) {
+	str = strings.ToLower(strings.TrimSpace(str))
+	switch {
+	case str == "" || str == "none" || str == "transparent":
+		return color.NRGBA{}, false
+	case strings.HasPrefix(str, "#"):
+		return parseHex(str[1:])
+	case strings.HasPrefix(str, "rgb"):
+		return parseRGB(str)
+	default:
+		hex, ok := charts.NamedColor(str)
+		if !ok {
+			return color.NRGBA{}, false
+		}
+		return parseHex(hex[1:])
+	}
+}
+
+func parseHex(str string) (color.NRGBA, bool) {
+	switch len(str) {
+	case 3, 4:
+		var buf []byte
+		for i := range str {
+			buf = append(buf, str[i], str[i])
+		}
+		str = string(buf)
+	case 6, 8:
+	default:
+		return color.NRGBA{}, false
+	}
+	if len(str) == 6 {
+		str += "ff"
+	}
+	v, err := strconv.ParseUint(str, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	c := color.NRGBA{
+		R: uint8(v >> 24),
+		G: uint8(v >> 16),
+		B: uint8(v >> 8),
+		A: uint8(v),
+	}
+	return c, c.A > 0
+}
+
+func parseRGB(str string) (color.NRGBA, bool) {
+	beg := strings.Index(str, "(")
+	end := strings.Index(str, ")")
+	if beg < 0 || end < beg {
+		return color.NRGBA{}, false
+	}
+	var (
+		parts = strings.Split(str[beg+1:end], ",")
+		vs    = []uint8{0, 0, 0, 255}
+	)
+	if len(parts) < 3 || len(parts) > 4 {
+		return color.NRGBA{}, false
+	}
+	for i, p := range parts {
+		var (
+			p   = strings.TrimSpace(p)
+			pct = strings.HasSuffix(p, "%")
+		)
+		f, err := strconv.ParseFloat(strings.TrimSuffix(p, "%"), 64)
+		if err != nil {
+			return color.NRGBA{}, false
+		}
+		switch {
+		case pct:
+			f = f * 255 / 100
+		case i == 3:
+			f *= 255
+		}
+		vs[i] = uint8(clamp(f, 0, 255))
+	}
+	c := color.NRGBA{R: vs[0], G: vs[1], B: vs[2], A: vs[3]}
+	return c, c.A > 0
+}
+
+func withOpacity(c color.NRGBA, opacity float64) color.NRGBA {
+	c.A = uint8(clamp(float64(c.A)*opacity, 0, 255))
+	return c
+}
+
+func clamp(f, lo, hi float64) float64 {
+	if f < lo {
+		return lo
+	}
+	if f > hi {
+		return hi
+	}
+	return f
+}
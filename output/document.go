@@ -0,0 +1,273 @@
+package output
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+type node struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	parent   *node
+	children []*node
+}
+
+func (n *node) isText() bool {
+	return n.name == ""
+}
+
+func (n *node) ident() string {
+	return n.attrs["id"]
+}
+
+func (n *node) classes() []string {
+	return strings.Fields(n.attrs["class"])
+}
+
+func parseDocument(r io.Reader) (*node, error) {
+	var (
+		dec   = xml.NewDecoder(r)
+		root  *node
+		stack []*node
+	)
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &node{
+				name:  tok.Name.Local,
+				attrs: make(map[string]string),
+			}
+			for _, a := range tok.Attr {
+				n.attrs[a.Name.Local] = a.Value
+			}
+			if len(stack) > 0 {
+				n.parent = stack[len(stack)-1]
+				n.parent.children = append(n.parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) == 0 {
+				break
+			}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, &node{
+				text:   string(tok),
+				parent: parent,
+			})
+		}
+	}
+	if root == nil || root.name != "svg" {
+		return nil, errors.New("svg element not found in document")
+	}
+	return root, nil
+}
+
+type selector struct {
+	tag     string
+	id      string
+	classes []string
+}
+
+func (s selector) match(n *node) bool {
+	if s.tag != "" && s.tag != "*" && s.tag != n.name {
+		return false
+	}
+	if s.id != "" && s.id != n.ident() {
+		return false
+	}
+	list := n.classes()
+	for _, c := range s.classes {
+		var found bool
+		for _, x := range list {
+			if found = x == c; found {
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+type rule struct {
+	selectors []selector
+	decls     map[string]string
+	weight    int
+	order     int
+}
+
+func (r rule) match(n *node) bool {
+	if len(r.selectors) == 0 {
+		return false
+	}
+	var (
+		last = len(r.selectors) - 1
+		curr = n
+	)
+	if !r.selectors[last].match(curr) {
+		return false
+	}
+	for i := last - 1; i >= 0; i-- {
+		curr = curr.parent
+		for curr != nil && !r.selectors[i].match(curr) {
+			curr = curr.parent
+		}
+		if curr == nil {
+			return false
+		}
+	}
+	return true
+}
+
+type stylesheet []rule
+
+// parseStylesheet supports the subset of css used by the themes: compound
+// selectors made of tag, id and classes combined with descendant combinators.
+func parseStylesheet(str string) stylesheet {
+	var (
+		sheet stylesheet
+		order int
+	)
+	str = stripComments(str)
+	for {
+		beg := strings.Index(str, "{")
+		if beg < 0 {
+			break
+		}
+		end := strings.Index(str[beg:], "}")
+		if end < 0 {
+			break
+		}
+		var (
+			head  = str[:beg]
+			body  = str[beg+1 : beg+end]
+			decls = parseDeclarations(body)
+		)
+		str = str[beg+end+1:]
+		for _, group := range strings.Split(head, ",") {
+			r := rule{
+				decls: decls,
+				order: order,
+			}
+			for _, part := range strings.Fields(group) {
+				sel := parseSelector(part)
+				r.selectors = append(r.selectors, sel)
+				r.weight += 100*len(sel.id) + 10*len(sel.classes)
+				if sel.tag != "" && sel.tag != "*" {
+					r.weight++
+				}
+			}
+			if len(r.selectors) > 0 {
+				sheet = append(sheet, r)
+			}
+			order++
+		}
+	}
+	sort.SliceStable(sheet, func(i, j int) bool {
+		if sheet[i].weight == sheet[j].weight {
+			return sheet[i].order < sheet[j].order
+		}
+		return sheet[i].weight < sheet[j].weight
+	})
+	return sheet
+}
+
+func (s stylesheet) apply(n *node, props map[string]string) {
+	for _, r := range s {
+		if !r.match(n) {
+			continue
+		}
+		for k, v := range r.decls {
+			props[k] = v
+		}
+	}
+}
+
+func parseSelector(str string) selector {
+	var (
+		sel selector
+		ptr = &sel.tag
+		buf strings.Builder
+	)
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		if ptr != nil {
+			*ptr = buf.String()
+		} else {
+			sel.classes = append(sel.classes, buf.String())
+		}
+		buf.Reset()
+	}
+	for _, r := range str {
+		switch r {
+		case '#':
+			flush()
+			ptr = &sel.id
+		case '.':
+			flush()
+			ptr = nil
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	flush()
+	return sel
+}
+
+func parseDeclarations(str string) map[string]string {
+	decls := make(map[string]string)
+	for _, d := range strings.Split(str, ";") {
+		k, v, ok := strings.Cut(d, ":")
+		if !ok {
+			continue
+		}
+		k = strings.TrimSpace(k)
+		v = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(v), "!important"))
+		if k != "" {
+			decls[k] = v
+		}
+	}
+	return decls
+}
+
+func stripComments(str string) string {
+	var buf strings.Builder
+	for {
+		beg := strings.Index(str, "/*")
+		if beg < 0 {
+			break
+		}
+		buf.WriteString(str[:beg])
+		end := strings.Index(str[beg+2:], "*/")
+		if end < 0 {
+			str = ""
+			break
+		}
+		str = str[beg+2+end+2:]
+	}
+	buf.WriteString(str)
+	return buf.String()
+}
@@ -0,0 +1,540 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type point struct {
+	X float64
+	Y float64
+}
+
+func (p point) add(q point) point {
+	return point{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+func (p point) sub(q point) point {
+	return point{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+func (p point) mul(f float64) point {
+	return point{X: p.X * f, Y: p.Y * f}
+}
+
+func (p point) length() float64 {
+	return math.Hypot(p.X, p.Y)
+}
+
+type subpath struct {
+	points []point
+	closed bool
+}
+
+// matrix is an affine transformation [a c e; b d f; 0 0 1].
+type matrix struct {
+	A, B, C, D, E, F float64
+}
+
+func identity() matrix {
+	return matrix{A: 1, D: 1}
+}
+
+func translate(x, y float64) matrix {
+	return matrix{A: 1, D: 1, E: x, F: y}
+}
+
+func scale(x, y float64) matrix {
+	return matrix{A: x, D: y}
+}
+
+func rotate(deg float64) matrix {
+	var (
+		rad      = deg * math.Pi / 180
+		sin, cos = math.Sincos(rad)
+	)
+	return matrix{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// mul returns the matrix that applies o first and then m.
+func (m matrix) mul(o matrix) matrix {
+	return matrix{
+		A: m.A*o.A + m.C*o.B,
+		B: m.B*o.A + m.D*o.B,
+		C: m.A*o.C + m.C*o.D,
+		D: m.B*o.C + m.D*o.D,
+		E: m.A*o.E + m.C*o.F + m.E,
+		F: m.B*o.E + m.D*o.F + m.F,
+	}
+}
+
+func (m matrix) apply(p point) point {
+	return point{
+		X: m.A*p.X + m.C*p.Y + m.E,
+		Y: m.B*p.X + m.D*p.Y + m.F,
+	}
+}
+
+func (m matrix) scale() float64 {
+	return math.Sqrt(math.Abs(m.A*m.D - m.B*m.C))
+}
+
+func (m matrix) angle() float64 {
+	return math.Atan2(m.B, m.A)
+}
+
+func (m matrix) transform(paths []subpath) []subpath {
+	list := make([]subpath, 0, len(paths))
+	for _, s := range paths {
+		x := subpath{
+			closed: s.closed,
+			points: make([]point, len(s.points)),
+		}
+		for i := range s.points {
+			x.points[i] = m.apply(s.points[i])
+		}
+		list = append(list, x)
+	}
+	return list
+}
+
+func parseTransform(str string) (matrix, error) {
+	mat := identity()
+	for {
+		str = strings.TrimLeft(str, " ,\t\n")
+		if str == "" {
+			break
+		}
+		beg := strings.Index(str, "(")
+		end := strings.Index(str, ")")
+		if beg < 0 || end < beg {
+			return mat, fmt.Errorf("%s: invalid transform", str)
+		}
+		var (
+			name = strings.TrimSpace(str[:beg])
+			args = parseNumbers(str[beg+1 : end])
+			curr matrix
+		)
+		str = str[end+1:]
+		switch {
+		case name == "matrix" && len(args) == 6:
+			curr = matrix{A: args[0], B: args[1], C: args[2], D: args[3], E: args[4], F: args[5]}
+		case name == "translate" && len(args) == 1:
+			curr = translate(args[0], 0)
+		case name == "translate" && len(args) == 2:
+			curr = translate(args[0], args[1])
+		case name == "scale" && len(args) == 1:
+			curr = scale(args[0], args[0])
+		case name == "scale" && len(args) == 2:
+			curr = scale(args[0], args[1])
+		case name == "rotate" && len(args) == 1:
+			curr = rotate(args[0])
+		case name == "rotate" && len(args) == 3:
+			curr = translate(args[1], args[2]).mul(rotate(args[0])).mul(translate(-args[1], -args[2]))
+		case name == "skewX" && len(args) == 1:
+			curr = matrix{A: 1, D: 1, C: math.Tan(args[0] * math.Pi / 180)}
+		case name == "skewY" && len(args) == 1:
+			curr = matrix{A: 1, D: 1, B: math.Tan(args[0] * math.Pi / 180)}
+		default:
+			return mat, fmt.Errorf("%s: invalid transform", name)
+		}
+		mat = mat.mul(curr)
+	}
+	return mat, nil
+}
+
+func parseNumbers(str string) []float64 {
+	var list []float64
+	for _, f := range strings.FieldsFunc(str, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	}) {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			continue
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+type pathScanner struct {
+	str string
+	pos int
+}
+
+func (s *pathScanner) skip() {
+	for s.pos < len(s.str) {
+		switch s.str[s.pos] {
+		case ' ', ',', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *pathScanner) done() bool {
+	s.skip()
+	return s.pos >= len(s.str)
+}
+
+func (s *pathScanner) command() (byte, bool) {
+	s.skip()
+	if s.pos >= len(s.str) {
+		return 0, false
+	}
+	c := s.str[s.pos]
+	if strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) < 0 {
+		return 0, false
+	}
+	s.pos++
+	return c, true
+}
+
+func (s *pathScanner) number() (float64, error) {
+	s.skip()
+	var (
+		beg  = s.pos
+		dot  bool
+		exp  bool
+		prev byte
+	)
+	for s.pos < len(s.str) {
+		c := s.str[s.pos]
+		switch {
+		case c >= '0' && c <= '9':
+		case c == '.' && !dot && !exp:
+			dot = true
+		case (c == '-' || c == '+') && (s.pos == beg || prev == 'e' || prev == 'E'):
+		case (c == 'e' || c == 'E') && !exp && s.pos > beg:
+			exp = true
+		default:
+			return s.parse(beg)
+		}
+		prev = c
+		s.pos++
+	}
+	return s.parse(beg)
+}
+
+func (s *pathScanner) parse(beg int) (float64, error) {
+	if beg == s.pos {
+		return 0, fmt.Errorf("number expected at position %d", beg)
+	}
+	return strconv.ParseFloat(s.str[beg:s.pos], 64)
+}
+
+func (s *pathScanner) flag() (bool, error) {
+	s.skip()
+	if s.pos >= len(s.str) {
+		return false, fmt.Errorf("flag expected")
+	}
+	c := s.str[s.pos]
+	s.pos++
+	switch c {
+	case '0':
+		return false, nil
+	case '1':
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid flag %c", c)
+	}
+}
+
+func (s *pathScanner) numbers(n int) ([]float64, error) {
+	list := make([]float64, n)
+	for i := range list {
+		v, err := s.number()
+		if err != nil {
+			return nil, err
+		}
+		list[i] = v
+	}
+	return list, nil
+}
+
+// parsePath converts the path data into a list of polylines. Curves and arcs
+// are flattened in user space.
+func parsePath(str string) ([]subpath, error) {
+	var (
+		scan  = pathScanner{str: str}
+		list  []subpath
+		curr  subpath
+		pos   point
+		start point
+		ctrl  point
+		last  byte
+		cmd   byte
+	)
+	flush := func(closed bool) {
+		if len(curr.points) > 1 {
+			curr.closed = closed
+			list = append(list, curr)
+		}
+		curr = subpath{}
+	}
+	lineTo := func(p point) {
+		if len(curr.points) == 0 {
+			curr.points = append(curr.points, pos)
+		}
+		curr.points = append(curr.points, p)
+		pos = p
+	}
+	for !scan.done() {
+		if c, ok := scan.command(); ok {
+			cmd = c
+		} else if cmd == 0 {
+			return nil, fmt.Errorf("path should start with a command")
+		}
+		var (
+			rel    = cmd >= 'a' && cmd <= 'z'
+			origin point
+		)
+		if rel {
+			origin = pos
+		}
+		switch cmd {
+		case 'M', 'm':
+			args, err := scan.numbers(2)
+			if err != nil {
+				return nil, err
+			}
+			flush(false)
+			pos = origin.add(point{X: args[0], Y: args[1]})
+			start = pos
+			curr.points = append(curr.points, pos)
+			if rel {
+				cmd = 'l'
+			} else {
+				cmd = 'L'
+			}
+		case 'L', 'l':
+			args, err := scan.numbers(2)
+			if err != nil {
+				return nil, err
+			}
+			lineTo(origin.add(point{X: args[0], Y: args[1]}))
+		case 'H', 'h':
+			args, err := scan.numbers(1)
+			if err != nil {
+				return nil, err
+			}
+			p := point{X: args[0], Y: pos.Y}
+			if rel {
+				p.X += pos.X
+			}
+			lineTo(p)
+		case 'V', 'v':
+			args, err := scan.numbers(1)
+			if err != nil {
+				return nil, err
+			}
+			p := point{X: pos.X, Y: args[0]}
+			if rel {
+				p.Y += pos.Y
+			}
+			lineTo(p)
+		case 'C', 'c', 'S', 's':
+			var (
+				c1, c2, end point
+				smooth      = cmd == 'S' || cmd == 's'
+			)
+			if smooth {
+				args, err := scan.numbers(4)
+				if err != nil {
+					return nil, err
+				}
+				c1 = pos
+				if strings.IndexByte("CcSs", last) >= 0 {
+					c1 = pos.mul(2).sub(ctrl)
+				}
+				c2 = origin.add(point{X: args[0], Y: args[1]})
+				end = origin.add(point{X: args[2], Y: args[3]})
+			} else {
+				args, err := scan.numbers(6)
+				if err != nil {
+					return nil, err
+				}
+				c1 = origin.add(point{X: args[0], Y: args[1]})
+				c2 = origin.add(point{X: args[2], Y: args[3]})
+				end = origin.add(point{X: args[4], Y: args[5]})
+			}
+			for _, p := range flattenCubic(pos, c1, c2, end) {
+				lineTo(p)
+			}
+			ctrl = c2
+		case 'Q', 'q', 'T', 't':
+			var (
+				c1, end point
+				smooth  = cmd == 'T' || cmd == 't'
+			)
+			if smooth {
+				args, err := scan.numbers(2)
+				if err != nil {
+					return nil, err
+				}
+				c1 = pos
+				if strings.IndexByte("QqTt", last) >= 0 {
+					c1 = pos.mul(2).sub(ctrl)
+				}
+				end = origin.add(point{X: args[0], Y: args[1]})
+			} else {
+				args, err := scan.numbers(4)
+				if err != nil {
+					return nil, err
+				}
+				c1 = origin.add(point{X: args[0], Y: args[1]})
+				end = origin.add(point{X: args[2], Y: args[3]})
+			}
+			var (
+				q1 = pos.add(c1.sub(pos).mul(2.0 / 3))
+				q2 = end.add(c1.sub(end).mul(2.0 / 3))
+			)
+			for _, p := range flattenCubic(pos, q1, q2, end) {
+				lineTo(p)
+			}
+			ctrl = c1
+		case 'A', 'a':
+			args, err := scan.numbers(3)
+			if err != nil {
+				return nil, err
+			}
+			large, err := scan.flag()
+			if err != nil {
+				return nil, err
+			}
+			sweep, err := scan.flag()
+			if err != nil {
+				return nil, err
+			}
+			dest, err := scan.numbers(2)
+			if err != nil {
+				return nil, err
+			}
+			end := origin.add(point{X: dest[0], Y: dest[1]})
+			for _, p := range flattenArc(pos, end, args[0], args[1], args[2], large, sweep) {
+				lineTo(p)
+			}
+		case 'Z', 'z':
+			if len(curr.points) > 0 {
+				flush(true)
+			}
+			pos = start
+			curr.points = append(curr.points, pos)
+		}
+		last = cmd
+	}
+	flush(false)
+	return list, nil
+}
+
+func flattenCubic(p0, p1, p2, p3 point) []point {
+	var (
+		size = p1.sub(p0).length() + p2.sub(p1).length() + p3.sub(p2).length()
+		n    = int(math.Ceil(size / 2))
+		list []point
+	)
+	if n < 4 {
+		n = 4
+	} else if n > 200 {
+		n = 200
+	}
+	for i := 1; i <= n; i++ {
+		var (
+			t  = float64(i) / float64(n)
+			u  = 1 - t
+			c0 = u * u * u
+			c1 = 3 * u * u * t
+			c2 = 3 * u * t * t
+			c3 = t * t * t
+		)
+		list = append(list, point{
+			X: c0*p0.X + c1*p1.X + c2*p2.X + c3*p3.X,
+			Y: c0*p0.Y + c1*p1.Y + c2*p2.Y + c3*p3.Y,
+		})
+	}
+	return list
+}
+
+// flattenArc converts an arc given in endpoint parametrization to its center
+// parametrization and samples it.
+func flattenArc(p0, p1 point, rx, ry, rot float64, large, sweep bool) []point {
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 || (p0.X == p1.X && p0.Y == p1.Y) {
+		return []point{p1}
+	}
+	var (
+		phi      = rot * math.Pi / 180
+		sin, cos = math.Sincos(phi)
+		dx       = (p0.X - p1.X) / 2
+		dy       = (p0.Y - p1.Y) / 2
+		x1       = cos*dx + sin*dy
+		y1       = -sin*dx + cos*dy
+	)
+	if lambda := (x1*x1)/(rx*rx) + (y1*y1)/(ry*ry); lambda > 1 {
+		lambda = math.Sqrt(lambda)
+		rx *= lambda
+		ry *= lambda
+	}
+	var (
+		num = rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+		den = rx*rx*y1*y1 + ry*ry*x1*x1
+		k   = math.Sqrt(math.Max(0, num/den))
+	)
+	if large == sweep {
+		k = -k
+	}
+	var (
+		cx1 = k * rx * y1 / ry
+		cy1 = -k * ry * x1 / rx
+		cx  = cos*cx1 - sin*cy1 + (p0.X+p1.X)/2
+		cy  = sin*cx1 + cos*cy1 + (p0.Y+p1.Y)/2
+		beg = vectorAngle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+		ext = vectorAngle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	)
+	if !sweep && ext > 0 {
+		ext -= 2 * math.Pi
+	} else if sweep && ext < 0 {
+		ext += 2 * math.Pi
+	}
+	n := int(math.Ceil(math.Abs(ext) * math.Max(rx, ry) / 2))
+	if n < 4 {
+		n = 4
+	} else if n > 360 {
+		n = 360
+	}
+	var list []point
+	for i := 1; i <= n; i++ {
+		var (
+			a      = beg + ext*float64(i)/float64(n)
+			sa, ca = math.Sincos(a)
+		)
+		list = append(list, point{
+			X: cx + rx*ca*cos - ry*sa*sin,
+			Y: cy + rx*ca*sin + ry*sa*cos,
+		})
+	}
+	list[len(list)-1] = p1
+	return list
+}
+
+func vectorAngle(ux, uy, vx, vy float64) float64 {
+	return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+}
+
+func ellipse(cx, cy, rx, ry float64) subpath {
+	n := int(math.Ceil(math.Max(rx, ry) * math.Pi / 2))
+	if n < 16 {
+		n = 16
+	} else if n > 360 {
+		n = 360
+	}
+	s := subpath{closed: true}
+	for i := 0; i < n; i++ {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / float64(n))
+		s.points = append(s.points, point{X: cx + rx*cos, Y: cy + ry*sin})
+	}
+	return s
+}
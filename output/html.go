@@ -0,0 +1,123 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/midbel/svg"
+)
+
+const htmlStyle = `
+body { margin: 0; font-family: sans-serif; }
+.chart { display: inline-block; }
+.chart [data-x] { cursor: crosshair; }
+.chart [data-x]:hover { stroke: black; stroke-width: 1px; }
+.chart g.area { transition: opacity 0.15s; }
+.chart g.area.dimmed { opacity: 0.25; }
+.chart g.area.hidden { display: none; }
+.chart g.legend-item[data-serie] { cursor: pointer; }
+.chart g.legend-item.legend-off { opacity: 0.35; }
+.chart-tooltip {
+	position: absolute;
+	display: none;
+	pointer-events: none;
+	padding: 4px 8px;
+	font-size: 12px;
+	background: rgba(255, 255, 255, 0.95);
+	border: 1px solid #999;
+	border-radius: 3px;
+	box-shadow: 0 1px 3px rgba(0, 0, 0, 0.2);
+	white-space: nowrap;
+}
+`
+
+const htmlScript = `
+(function() {
+	var tip = document.querySelector(".chart-tooltip");
+	function escape(str) {
+		var div = document.createElement("div");
+		div.textContent = str;
+		return div.innerHTML;
+	}
+	function serieOf(el) {
+		return el.closest("g.area");
+	}
+	function highlight(area) {
+		document.querySelectorAll(".chart g.area").forEach(function(a) {
+			var dim = area !== null && a !== area && a.ownerSVGElement === area.ownerSVGElement;
+			a.classList.toggle("dimmed", dim);
+		});
+	}
+	function move(e) {
+		tip.style.left = (e.pageX + 12) + "px";
+		tip.style.top = (e.pageY + 12) + "px";
+	}
+	function show(el, e) {
+		var lines = [],
+			area = serieOf(el),
+			title = area && area.getAttribute("data-title");
+		if (title) {
+			lines.push("<b>" + escape(title) + "</b>");
+		}
+		if (el.hasAttribute("data-label")) {
+			lines.push(escape(el.getAttribute("data-label")));
+		}
+		lines.push("x: " + escape(el.getAttribute("data-x")));
+		lines.push("y: " + escape(el.getAttribute("data-y")));
+		tip.innerHTML = lines.join("<br>");
+		tip.style.display = "block";
+		move(e);
+	}
+	document.querySelectorAll(".chart [data-x]").forEach(function(el) {
+		el.addEventListener("mouseenter", function(e) {
+			show(el, e);
+			highlight(serieOf(el));
+		});
+		el.addEventListener("mousemove", move);
+		el.addEventListener("mouseleave", function() {
+			tip.style.display = "none";
+			highlight(null);
+		});
+	});
+	document.querySelectorAll(".chart g.legend-item[data-serie]").forEach(function(item) {
+		item.addEventListener("click", function() {
+			var off = item.classList.toggle("legend-off"),
+				id = item.getAttribute("data-serie");
+			item.ownerSVGElement.querySelectorAll("g.area").forEach(function(a) {
+				if (a.id === id) {
+					a.classList.toggle("hidden", off);
+				}
+			});
+		});
+	});
+})();
+`
+
+// WriteHTML writes a self contained html page embedding the svg document. The
+// page comes with a small script giving a tooltip on elements carrying data
+// attributes, highlighting of the serie under the cursor and legend items
+// toggling their serie.
+func WriteHTML(w io.Writer, el svg.Element) error {
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, el); err != nil {
+		return err
+	}
+	doc := buf.String()
+	if strings.HasPrefix(doc, "<?xml") {
+		if x := strings.Index(doc, "?>"); x >= 0 {
+			doc = strings.TrimSpace(doc[x+2:])
+		}
+	}
+	ws := bufio.NewWriter(w)
+	ws.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	ws.WriteString("<style>")
+	ws.WriteString(htmlStyle)
+	ws.WriteString("</style>\n</head>\n<body>\n<div class=\"chart\">\n")
+	ws.WriteString(doc)
+	ws.WriteString("\n</div>\n<div class=\"chart-tooltip\"></div>\n<script>")
+	ws.WriteString(htmlScript)
+	ws.WriteString("</script>\n</body>\n</html>\n")
+	return ws.Flush()
+}
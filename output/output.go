@@ -0,0 +1,93 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/midbel/svg"
+)
+
+const (
+	FormatSVG  = "svg"
+	FormatPNG  = "png"
+	FormatPDF  = "pdf"
+	FormatHTML = "html"
+)
+
+var (
+	// Background is used to paint the image before drawing the element when
+	// the document does not define its own background.
+	Background = "white"
+
+	// Scale is the number of pixels used for each unit of the svg document
+	// when rasterizing it.
+	Scale = 1.0
+)
+
+// FormatFromPath returns the output format to use according to the extension
+// of the given file. It defaults to svg.
+func FormatFromPath(file string) string {
+	switch ext := strings.ToLower(filepath.Ext(file)); ext {
+	case ".png":
+		return FormatPNG
+	case ".pdf":
+		return FormatPDF
+	case ".html", ".htm":
+		return FormatHTML
+	default:
+		return FormatSVG
+	}
+}
+
+func Write(w io.Writer, el svg.Element, format string) error {
+	switch format {
+	case FormatSVG, "":
+		return WriteSVG(w, el)
+	case FormatPNG:
+		return WritePNG(w, el)
+	case FormatPDF:
+		return WritePDF(w, el)
+	case FormatHTML:
+		return WriteHTML(w, el)
+	default:
+		return fmt.Errorf("%s: unsupported output format", format)
+	}
+}
+
+func WriteSVG(w io.Writer, el svg.Element) error {
+	ws := bufio.NewWriter(w)
+	el.Render(ws)
+	return ws.Flush()
+}
+
+func WritePNG(w io.Writer, el svg.Element) error {
+	sc, err := createScene(el, scale(Scale, Scale))
+	if err != nil {
+		return err
+	}
+	return rasterize(w, sc)
+}
+
+func WritePDF(w io.Writer, el svg.Element) error {
+	sc, err := createScene(el, identity())
+	if err != nil {
+		return err
+	}
+	return writeDocument(w, sc)
+}
+
+func createScene(el svg.Element, mat matrix) (*scene, error) {
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, el); err != nil {
+		return nil, err
+	}
+	root, err := parseDocument(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buildScene(root, mat)
+}
@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/midbel/svg"
+)
+
+func createElement() svg.Element {
+	var (
+		root = svg.NewSVG()
+		rec  svg.Rect
+	)
+	root.Dim = svg.NewDim(40, 20)
+	rec.Pos = svg.NewPos(10, 0)
+	rec.Dim = svg.NewDim(20, 20)
+	rec.Fill = svg.NewFill("red")
+	root.Append(rec.AsElement())
+	return &root
+}
+
+func TestWritePNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, createElement()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("invalid png: %s", err)
+	}
+	if b := img.Bounds(); b.Dx() != 40 || b.Dy() != 20 {
+		t.Fatalf("wrong image size: %dx%d", b.Dx(), b.Dy())
+	}
+	tests := []struct {
+		X, Y    int
+		R, G, B uint32
+	}{
+		{X: 5, Y: 10, R: 0xffff, G: 0xffff, B: 0xffff},
+		{X: 15, Y: 10, R: 0xffff},
+		{X: 35, Y: 10, R: 0xffff, G: 0xffff, B: 0xffff},
+	}
+	for _, c := range tests {
+		r, g, b, _ := img.At(c.X, c.Y).RGBA()
+		if r != c.R || g != c.G || b != c.B {
+			t.Errorf("pixel at %d,%d: want %x,%x,%x, got %x,%x,%x", c.X, c.Y, c.R, c.G, c.B, r, g, b)
+		}
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, createElement()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	str := buf.String()
+	if !strings.HasPrefix(str, "%PDF-") {
+		t.Errorf("missing pdf header")
+	}
+	if !strings.Contains(str, "/MediaBox [0 0 40 20]") {
+		t.Errorf("wrong page size")
+	}
+	if !strings.HasSuffix(str, "%%EOF\n") {
+		t.Errorf("missing end of file marker")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	paths, err := parsePath("M10,10 h10 v10 H10 Z M30 30 A5 5 0 0 1 40 30")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 subpaths, got %d", len(paths))
+	}
+	if !paths[0].closed || len(paths[0].points) != 4 {
+		t.Errorf("first subpath: unexpected points %v", paths[0].points)
+	}
+	var (
+		arc = paths[1].points
+		end = arc[len(arc)-1]
+	)
+	if end.X != 40 || end.Y != 30 {
+		t.Errorf("arc should end at 40,30, got %v", end)
+	}
+	for _, p := range arc[1 : len(arc)-1] {
+		if d := math.Hypot(p.X-35, p.Y-30); math.Abs(d-5) > 1e-6 {
+			t.Errorf("point %v not on the arc (distance %f)", p, d)
+		}
+		if p.Y > 30 {
+			t.Errorf("point %v on the wrong side of the arc", p)
+		}
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, createElement()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	str := buf.String()
+	if !strings.HasPrefix(str, "<!DOCTYPE html>") {
+		t.Errorf("missing doctype")
+	}
+	if strings.Contains(str, "<?xml") {
+		t.Errorf("xml prolog should be removed")
+	}
+	for _, s := range []string{"<svg", "<script>", "chart-tooltip"} {
+		if !strings.Contains(str, s) {
+			t.Errorf("%s not found in document", s)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// helveticaWidths gives the advance, in thousandths of em, of the printable
+// ascii characters of the standard Helvetica font.
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+func textAdvance(str string, size float64) float64 {
+	var width int
+	for _, r := range str {
+		if r >= ' ' && r <= '~' {
+			width += helveticaWidths[r-' ']
+		} else {
+			width += 556
+		}
+	}
+	return float64(width) * size / 1000
+}
+
+type pdfWriter struct {
+	content bytes.Buffer
+	alphas  map[uint8]string
+}
+
+func writeDocument(w io.Writer, sc *scene) error {
+	pw := pdfWriter{
+		alphas: make(map[uint8]string),
+	}
+	pw.writef("1 0 0 -1 0 %s cm\n", pdfNumber(sc.height))
+	if sc.hasBackground {
+		pw.setFill(sc.background)
+		pw.writef("0 0 %s %s re f\n", pdfNumber(sc.width), pdfNumber(sc.height))
+	}
+	for _, it := range sc.items {
+		switch it := it.(type) {
+		case *shape:
+			pw.writeShape(it)
+		case *label:
+			pw.writeLabel(it)
+		}
+	}
+	return pw.flush(w, sc)
+}
+
+func (w *pdfWriter) writeShape(sh *shape) {
+	if !w.beginClip(sh.clips) {
+		return
+	}
+	if sh.hasFill {
+		w.setFill(sh.fill)
+		w.writePaths(sh.paths, true)
+		if sh.evenodd {
+			w.writef("f*\n")
+		} else {
+			w.writef("f\n")
+		}
+	}
+	if sh.hasStroke {
+		w.setStroke(sh.stroke)
+		w.writef("%s w 1 j ", pdfNumber(sh.width))
+		switch sh.cap {
+		case "round":
+			w.writef("1 J ")
+		case "square":
+			w.writef("2 J ")
+		default:
+			w.writef("0 J ")
+		}
+		var dashes []string
+		for _, d := range sh.dashes {
+			dashes = append(dashes, pdfNumber(d))
+		}
+		w.writef("[%s] %s d\n", strings.Join(dashes, " "), pdfNumber(sh.offset))
+		w.writePaths(sh.paths, false)
+		w.writef("S\n")
+	}
+	w.writef("Q\n")
+}
+
+func (w *pdfWriter) writeLabel(lb *label) {
+	if !w.beginClip(lb.clips) {
+		return
+	}
+	var (
+		font     = fontRegular
+		sin, cos = math.Sincos(lb.angle)
+		dx       = anchorShift(lb.anchor, textAdvance(lb.text, lb.size))
+		dy       = baselineShift(lb.baseline) * lb.size
+		x        = lb.pos.X + dx*cos - dy*sin
+		y        = lb.pos.Y + dx*sin + dy*cos
+	)
+	if lb.bold {
+		font = fontBold
+	}
+	w.setFill(lb.fill)
+	w.writef("BT /%s %s Tf %s %s %s %s %s %s Tm (%s) Tj ET\n", font, pdfNumber(lb.size),
+		pdfNumber(cos), pdfNumber(sin), pdfNumber(sin), pdfNumber(-cos), pdfNumber(x), pdfNumber(y),
+		pdfString(lb.text))
+	w.writef("Q\n")
+}
+
+// beginClip saves the graphic state and intersects the clipping area with the
+// given regions. It reports false when nothing can be drawn.
+func (w *pdfWriter) beginClip(regions []region) bool {
+	for _, rg := range regions {
+		if len(rg) == 0 {
+			return false
+		}
+	}
+	w.writef("q\n")
+	for _, rg := range regions {
+		w.writePaths(rg, true)
+		w.writef("W n\n")
+	}
+	return true
+}
+
+func (w *pdfWriter) writePaths(paths []subpath, close bool) {
+	for _, s := range paths {
+		if len(s.points) == 0 {
+			continue
+		}
+		for i, p := range s.points {
+			op := "l"
+			if i == 0 {
+				op = "m"
+			}
+			w.writef("%s %s %s ", pdfNumber(p.X), pdfNumber(p.Y), op)
+		}
+		if close || s.closed {
+			w.writef("h")
+		}
+		w.writef("\n")
+	}
+}
+
+func (w *pdfWriter) setFill(c color.NRGBA) {
+	w.writef("/%s gs %s %s %s rg\n", w.alpha(c.A), pdfColor(c.R), pdfColor(c.G), pdfColor(c.B))
+}
+
+func (w *pdfWriter) setStroke(c color.NRGBA) {
+	w.writef("/%s gs %s %s %s RG\n", w.alpha(c.A), pdfColor(c.R), pdfColor(c.G), pdfColor(c.B))
+}
+
+func (w *pdfWriter) alpha(a uint8) string {
+	name, ok := w.alphas[a]
+	if !ok {
+		name = fmt.Sprintf("GS%d", len(w.alphas))
+		w.alphas[a] = name
+	}
+	return name
+}
+
+func (w *pdfWriter) writef(format string, args ...any) {
+	fmt.Fprintf(&w.content, format, args...)
+}
+
+func (w *pdfWriter) flush(ws io.Writer, sc *scene) error {
+	var stream bytes.Buffer
+	z := zlib.NewWriter(&stream)
+	if _, err := z.Write(w.content.Bytes()); err != nil {
+		return err
+	}
+	if err := z.Close(); err != nil {
+		return err
+	}
+
+	var (
+		keys   []int
+		states []string
+	)
+	for a := range w.alphas {
+		keys = append(keys, int(a))
+	}
+	sort.Ints(keys)
+
+	var objects []string
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+	objects = append(objects, "<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	objects = append(objects, "") // page: written once all resources are known
+	objects = append(objects, fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.String()))
+	objects = append(objects, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	objects = append(objects, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for _, k := range keys {
+		var (
+			a   = pdfNumber(float64(k) / 255)
+			obj = fmt.Sprintf("<< /Type /ExtGState /ca %s /CA %s >>", a, a)
+		)
+		objects = append(objects, obj)
+		states = append(states, fmt.Sprintf("/%s %d 0 R", w.alphas[uint8(k)], len(objects)))
+	}
+	objects[2] = fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Contents 4 0 R /Resources << /Font << /%s 5 0 R /%s 6 0 R >> /ExtGState << %s >> >> >>",
+		pdfNumber(sc.width), pdfNumber(sc.height), fontRegular, fontBold, strings.Join(states, " "))
+
+	var (
+		out     = bufio.NewWriter(ws)
+		offsets = make([]int, len(objects))
+		written int
+	)
+	write := func(str string) {
+		n, _ := out.WriteString(str)
+		written += n
+	}
+	write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	for i, obj := range objects {
+		offsets[i] = written
+		write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, obj))
+	}
+	xref := written
+	write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+	for _, off := range offsets {
+		write(fmt.Sprintf("%010d 00000 n \n", off))
+	}
+	write(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref))
+	return out.Flush()
+}
+
+func pdfNumber(f float64) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "0"
+	}
+	str := strconv.FormatFloat(f, 'f', 3, 64)
+	str = strings.TrimRight(strings.TrimRight(str, "0"), ".")
+	if str == "-0" || str == "" {
+		return "0"
+	}
+	return str
+}
+
+func pdfColor(c uint8) string {
+	return pdfNumber(float64(c) / 255)
+}
+
+// pdfString escapes the text to be written as a literal string encoded with
+// WinAnsiEncoding. Characters out of latin-1 are replaced by '?'.
+func pdfString(str string) string {
+	var buf strings.Builder
+	for _, r := range str {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r >= ' ' && r <= '~':
+			buf.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&buf, "\\%03o", r)
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
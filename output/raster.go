@@ -0,0 +1,265 @@
+package output
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+func rasterize(w io.Writer, sc *scene) error {
+	var (
+		width  = int(math.Ceil(sc.width))
+		height = int(math.Ceil(sc.height))
+	)
+	if width <= 0 || height <= 0 {
+		return errors.New("image can not be empty")
+	}
+	var (
+		img   = image.NewRGBA(image.Rect(0, 0, width, height))
+		clips = make(map[*subpath]*image.Alpha)
+	)
+	if sc.hasBackground {
+		draw.Draw(img, img.Bounds(), image.NewUniform(sc.background), image.Point{}, draw.Src)
+	}
+	for _, it := range sc.items {
+		switch it := it.(type) {
+		case *shape:
+			if it.hasFill {
+				mask := coverage(it.paths, it.evenodd, img.Bounds())
+				paint(img, mask, it.fill, it.clips, clips)
+			}
+			if it.hasStroke {
+				outline := strokePaths(it.paths, it.width, it.dashes, it.offset, it.cap)
+				mask := coverage(outline, false, img.Bounds())
+				paint(img, mask, it.stroke, it.clips, clips)
+			}
+		case *label:
+			mask := coverage(outlineText(it), false, img.Bounds())
+			paint(img, mask, it.fill, it.clips, clips)
+		}
+	}
+	return png.Encode(w, img)
+}
+
+func paint(img *image.RGBA, mask *image.Alpha, c color.Color, regions []region, cache map[*subpath]*image.Alpha) {
+	if mask == nil {
+		return
+	}
+	for _, rg := range regions {
+		if len(rg) == 0 {
+			return
+		}
+		clip, ok := cache[&rg[0]]
+		if !ok {
+			clip = coverage(rg, false, img.Bounds())
+			cache[&rg[0]] = clip
+		}
+		if clip == nil {
+			return
+		}
+		intersect(mask, clip)
+	}
+	draw.DrawMask(img, mask.Rect, image.NewUniform(c), image.Point{}, mask, mask.Rect.Min, draw.Over)
+}
+
+func intersect(mask, clip *image.Alpha) {
+	b := mask.Rect
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			var (
+				i = mask.PixOffset(x, y)
+				a = uint32(mask.Pix[i])
+				c uint32
+			)
+			if (image.Point{X: x, Y: y}).In(clip.Rect) {
+				c = uint32(clip.Pix[clip.PixOffset(x, y)])
+			}
+			mask.Pix[i] = uint8(a * c / 255)
+		}
+	}
+}
+
+// coverage computes the area of each pixel covered by the given polygons
+// using signed area accumulation. The result is limited to the bounding box
+// of the polygons.
+func coverage(paths []subpath, evenodd bool, bounds image.Rectangle) *image.Alpha {
+	var (
+		minx = math.Inf(1)
+		miny = math.Inf(1)
+		maxx = math.Inf(-1)
+		maxy = math.Inf(-1)
+	)
+	for _, s := range paths {
+		for _, p := range s.points {
+			minx = math.Min(minx, p.X)
+			miny = math.Min(miny, p.Y)
+			maxx = math.Max(maxx, p.X)
+			maxy = math.Max(maxy, p.Y)
+		}
+	}
+	if math.IsInf(minx, 0) || math.IsNaN(minx) || math.IsNaN(maxx) {
+		return nil
+	}
+	rect := image.Rect(int(math.Floor(minx)), int(math.Floor(miny)), int(math.Ceil(maxx))+1, int(math.Ceil(maxy))+1)
+	rect = rect.Intersect(bounds)
+	if rect.Empty() {
+		return nil
+	}
+	acc := accumulator{
+		left:   float64(rect.Min.X),
+		top:    float64(rect.Min.Y),
+		width:  rect.Dx(),
+		height: rect.Dy(),
+		stride: rect.Dx() + 2,
+	}
+	acc.cells = make([]float64, acc.stride*acc.height)
+	for _, s := range paths {
+		if len(s.points) < 2 {
+			continue
+		}
+		for i := 1; i < len(s.points); i++ {
+			acc.line(s.points[i-1], s.points[i])
+		}
+		acc.line(s.points[len(s.points)-1], s.points[0])
+	}
+	mask := image.NewAlpha(rect)
+	for y := 0; y < acc.height; y++ {
+		var (
+			sum  float64
+			row  = acc.cells[y*acc.stride:]
+			base = mask.PixOffset(rect.Min.X, rect.Min.Y+y)
+		)
+		for x := 0; x < acc.width; x++ {
+			sum += row[x]
+			v := math.Abs(sum)
+			if evenodd {
+				v = math.Mod(v, 2)
+				if v > 1 {
+					v = 2 - v
+				}
+			}
+			mask.Pix[base+x] = uint8(math.Min(v, 1)*255 + 0.5)
+		}
+	}
+	return mask
+}
+
+type accumulator struct {
+	left   float64
+	top    float64
+	width  int
+	height int
+	stride int
+	cells  []float64
+}
+
+// line splits the segment at the vertical borders of the accumulator. The
+// parts outside are projected on the borders: they still contribute to the
+// pixels at their right.
+func (a *accumulator) line(p0, p1 point) {
+	p0 = p0.sub(point{X: a.left, Y: a.top})
+	p1 = p1.sub(point{X: a.left, Y: a.top})
+	if p0.Y == p1.Y {
+		return
+	}
+	var (
+		right = float64(a.width)
+		cuts  []float64
+	)
+	for _, x := range []float64{0, right} {
+		if (p0.X < x && p1.X > x) || (p0.X > x && p1.X < x) {
+			cuts = append(cuts, (x-p0.X)/(p1.X-p0.X))
+		}
+	}
+	if len(cuts) == 2 && cuts[0] > cuts[1] {
+		cuts[0], cuts[1] = cuts[1], cuts[0]
+	}
+	prev := p0
+	for _, t := range append(cuts, 1) {
+		next := p1
+		if t < 1 {
+			next = p0.add(p1.sub(p0).mul(t))
+		}
+		a.segment(
+			point{X: clamp(prev.X, 0, right), Y: prev.Y},
+			point{X: clamp(next.X, 0, right), Y: next.Y},
+		)
+		prev = next
+	}
+}
+
+func (a *accumulator) segment(p0, p1 point) {
+	if p0.Y == p1.Y {
+		return
+	}
+	dir := 1.0
+	if p0.Y > p1.Y {
+		dir = -1
+		p0, p1 = p1, p0
+	}
+	var (
+		dxdy = (p1.X - p0.X) / (p1.Y - p0.Y)
+		x    = p0.X
+		beg  = int(math.Max(0, math.Floor(p0.Y)))
+		end  = int(math.Min(float64(a.height), math.Ceil(p1.Y)))
+	)
+	if p0.Y < 0 {
+		x -= p0.Y * dxdy
+	}
+	for y := beg; y < end; y++ {
+		var (
+			row   = a.cells[y*a.stride : (y+1)*a.stride]
+			fy    = float64(y)
+			dy    = math.Min(fy+1, p1.Y) - math.Max(fy, p0.Y)
+			xnext = x + dxdy*dy
+			d     = dy * dir
+			x0    = math.Min(x, xnext)
+			x1    = math.Max(x, xnext)
+			x0f   = math.Floor(x0)
+			x0i   = int(x0f)
+			x1c   = math.Ceil(x1)
+			x1i   = int(x1c)
+		)
+		if x1i <= x0i+1 {
+			xm := 0.5*(x+xnext) - x0f
+			a.add(row, x0i, d-d*xm)
+			a.add(row, x0i+1, d*xm)
+		} else {
+			var (
+				s   = 1 / (x1 - x0)
+				x0d = x0 - x0f
+				a0  = 0.5 * s * (1 - x0d) * (1 - x0d)
+				x1d = x1 - x1c + 1
+				am  = 0.5 * s * x1d * x1d
+				a1  = s * (1.5 - x0d)
+			)
+			a.add(row, x0i, d*a0)
+			if x1i == x0i+2 {
+				a.add(row, x0i+1, d*(1-a0-am))
+			} else {
+				a.add(row, x0i+1, d*(a1-a0))
+				for xi := x0i + 2; xi < x1i-1; xi++ {
+					a.add(row, xi, d*s)
+				}
+				a2 := a1 + float64(x1i-x0i-3)*s
+				a.add(row, x1i-1, d*(1-a2-am))
+			}
+			a.add(row, x1i, d*am)
+		}
+		x = xnext
+	}
+}
+
+func (a *accumulator) add(row []float64, x int, v float64) {
+	if x < 0 {
+		x = 0
+	}
+	if x >= len(row) {
+		x = len(row) - 1
+	}
+	row[x] += v
+}
@@ -0,0 +1,532 @@
+package output
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+type region []subpath
+
+type shape struct {
+	paths   []subpath
+	evenodd bool
+
+	fill    color.NRGBA
+	hasFill bool
+
+	stroke    color.NRGBA
+	hasStroke bool
+	width     float64
+	dashes    []float64
+	offset    float64
+	cap       string
+
+	clips []region
+}
+
+type label struct {
+	text     string
+	pos      point
+	size     float64
+	angle    float64
+	anchor   string
+	baseline string
+	bold     bool
+	fill     color.NRGBA
+	clips    []region
+}
+
+type scene struct {
+	width  float64
+	height float64
+
+	background    color.NRGBA
+	hasBackground bool
+
+	items []any
+
+	sheet stylesheet
+	clips map[string]*node
+}
+
+type state struct {
+	matrix
+
+	fill          string
+	fillOpacity   float64
+	fillRule      string
+	stroke        string
+	strokeOpacity float64
+	strokeWidth   float64
+	dashes        []float64
+	offset        float64
+	cap           string
+	opacity       float64
+
+	fontSize   float64
+	fontWeight string
+	anchor     string
+	baseline   string
+
+	clips []region
+}
+
+func defaultState(mat matrix) state {
+	return state{
+		matrix:        mat,
+		fill:          "black",
+		fillOpacity:   1,
+		stroke:        "none",
+		strokeOpacity: 1,
+		strokeWidth:   1,
+		opacity:       1,
+		fontSize:      16,
+		anchor:        "start",
+	}
+}
+
+var presentations = []string{
+	"fill",
+	"fill-opacity",
+	"fill-rule",
+	"stroke",
+	"stroke-width",
+	"stroke-opacity",
+	"stroke-dasharray",
+	"stroke-dashoffset",
+	"stroke-linecap",
+	"opacity",
+	"font-size",
+	"font-weight",
+	"text-anchor",
+	"dominant-baseline",
+	"display",
+	"visibility",
+	"clip-path",
+	"background",
+	"background-color",
+}
+
+func buildScene(root *node, mat matrix) (*scene, error) {
+	sc := scene{
+		clips: make(map[string]*node),
+	}
+	var css strings.Builder
+	visit(root, func(n *node) {
+		switch n.name {
+		case "style":
+			for _, c := range n.children {
+				css.WriteString(c.text)
+			}
+		case "clipPath":
+			if id := n.ident(); id != "" {
+				sc.clips[id] = n
+			}
+		}
+	})
+	sc.sheet = parseStylesheet(css.String())
+
+	var (
+		props = sc.properties(root)
+		box   = parseNumbers(root.attrs["viewBox"])
+	)
+	sc.width = parseLength(root.attrs["width"])
+	sc.height = parseLength(root.attrs["height"])
+	if len(box) == 4 {
+		if sc.width == 0 {
+			sc.width = box[2]
+		}
+		if sc.height == 0 {
+			sc.height = box[3]
+		}
+		if box[2] > 0 && box[3] > 0 {
+			vb := scale(sc.width/box[2], sc.height/box[3]).mul(translate(-box[0], -box[1]))
+			mat = mat.mul(vb)
+		}
+	}
+	bg := props["background-color"]
+	if bg == "" {
+		bg = props["background"]
+	}
+	if bg == "" {
+		bg = Background
+	}
+	sc.background, sc.hasBackground = parseColor(bg)
+	sc.width *= mat.scale()
+	sc.height *= mat.scale()
+
+	st, _, err := sc.inherit(root, defaultState(mat))
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range root.children {
+		if err := sc.walk(c, st); err != nil {
+			return nil, err
+		}
+	}
+	return &sc, nil
+}
+
+func visit(n *node, fn func(*node)) {
+	fn(n)
+	for _, c := range n.children {
+		visit(c, fn)
+	}
+}
+
+func (s *scene) properties(n *node) map[string]string {
+	props := make(map[string]string)
+	for _, p := range presentations {
+		if v, ok := n.attrs[p]; ok {
+			props[p] = v
+		}
+	}
+	s.sheet.apply(n, props)
+	for k, v := range parseDeclarations(n.attrs["style"]) {
+		props[k] = v
+	}
+	return props
+}
+
+func (s *scene) inherit(n *node, st state) (state, bool, error) {
+	props := s.properties(n)
+	if props["display"] == "none" || props["visibility"] == "hidden" {
+		return st, false, nil
+	}
+	if v, ok := props["fill"]; ok {
+		st.fill = v
+	}
+	if v, ok := props["stroke"]; ok {
+		st.stroke = v
+	}
+	if v, ok := props["fill-rule"]; ok {
+		st.fillRule = v
+	}
+	if v, ok := props["stroke-linecap"]; ok {
+		st.cap = v
+	}
+	if v, ok := props["text-anchor"]; ok {
+		st.anchor = v
+	}
+	if v, ok := props["dominant-baseline"]; ok {
+		st.baseline = v
+	}
+	if v, ok := props["font-weight"]; ok {
+		st.fontWeight = v
+	}
+	if v, ok := props["fill-opacity"]; ok {
+		st.fillOpacity = parseOpacity(v)
+	}
+	if v, ok := props["stroke-opacity"]; ok {
+		st.strokeOpacity = parseOpacity(v)
+	}
+	if v, ok := props["opacity"]; ok {
+		st.opacity *= parseOpacity(v)
+	}
+	if v, ok := props["stroke-width"]; ok {
+		st.strokeWidth = parseLength(v)
+	}
+	if v, ok := props["font-size"]; ok {
+		if f := parseLength(v); f > 0 {
+			st.fontSize = f
+		}
+	}
+	if v, ok := props["stroke-dasharray"]; ok {
+		st.dashes = parseDashes(v)
+	}
+	if v, ok := props["stroke-dashoffset"]; ok {
+		st.offset = parseLength(v)
+	}
+	if v, ok := n.attrs["transform"]; ok {
+		mat, err := parseTransform(v)
+		if err != nil {
+			return st, false, err
+		}
+		st.matrix = st.matrix.mul(mat)
+	}
+	if v, ok := props["clip-path"]; ok {
+		rg, err := s.clipRegion(v, st)
+		if err != nil {
+			return st, false, err
+		}
+		if rg != nil {
+			st.clips = append(append([]region{}, st.clips...), rg)
+		}
+	}
+	return st, true, nil
+}
+
+func (s *scene) walk(n *node, st state) error {
+	if n.isText() {
+		return nil
+	}
+	st, ok, err := s.inherit(n, st)
+	if err != nil || !ok {
+		return err
+	}
+	switch n.name {
+	case "svg":
+		var (
+			x   = parseLength(n.attrs["x"])
+			y   = parseLength(n.attrs["y"])
+			w   = parseLength(n.attrs["width"])
+			h   = parseLength(n.attrs["height"])
+			box = parseNumbers(n.attrs["viewBox"])
+		)
+		st.matrix = st.matrix.mul(translate(x, y))
+		if len(box) == 4 && box[2] > 0 && box[3] > 0 && w > 0 && h > 0 {
+			st.matrix = st.matrix.mul(scale(w/box[2], h/box[3])).mul(translate(-box[0], -box[1]))
+		}
+		fallthrough
+	case "g", "a", "switch":
+		for _, c := range n.children {
+			if err := s.walk(c, st); err != nil {
+				return err
+			}
+		}
+	case "text":
+		s.addText(n, st)
+	default:
+		paths, err := geometry(n)
+		if err != nil || len(paths) == 0 {
+			return err
+		}
+		if n.name == "line" {
+			st.fill = "none"
+		}
+		s.addShape(paths, st)
+	}
+	return nil
+}
+
+func (s *scene) addShape(paths []subpath, st state) {
+	sh := shape{
+		paths:   st.matrix.transform(paths),
+		evenodd: st.fillRule == "evenodd",
+		cap:     st.cap,
+		clips:   st.clips,
+	}
+	if c, ok := parseColor(st.fill); ok {
+		sh.fill = withOpacity(c, st.fillOpacity*st.opacity)
+		sh.hasFill = sh.fill.A > 0
+	}
+	if c, ok := parseColor(st.stroke); ok && st.strokeWidth > 0 {
+		var (
+			factor = st.matrix.scale()
+			dashes []float64
+		)
+		for _, d := range st.dashes {
+			dashes = append(dashes, d*factor)
+		}
+		sh.stroke = withOpacity(c, st.strokeOpacity*st.opacity)
+		sh.hasStroke = sh.stroke.A > 0
+		sh.width = st.strokeWidth * factor
+		sh.dashes = dashes
+		sh.offset = st.offset * factor
+	}
+	if sh.hasFill || sh.hasStroke {
+		s.items = append(s.items, &sh)
+	}
+}
+
+func (s *scene) addText(n *node, st state) {
+	cursor := point{
+		X: parseLength(n.attrs["x"]) + parseLength(n.attrs["dx"]),
+		Y: parseLength(n.attrs["y"]) + parseLength(n.attrs["dy"]),
+	}
+	for _, c := range n.children {
+		if c.isText() {
+			cursor = s.addLabel(c.text, cursor, st)
+			continue
+		}
+		if c.name != "tspan" {
+			continue
+		}
+		sub, ok, err := s.inherit(c, st)
+		if err != nil || !ok {
+			continue
+		}
+		if v, ok := c.attrs["x"]; ok {
+			cursor.X = parseLength(v)
+		}
+		if v, ok := c.attrs["y"]; ok {
+			cursor.Y = parseLength(v)
+		}
+		cursor.X += parseLength(c.attrs["dx"])
+		cursor.Y += parseLength(c.attrs["dy"])
+		for _, x := range c.children {
+			if x.isText() {
+				cursor = s.addLabel(x.text, cursor, sub)
+			}
+		}
+	}
+}
+
+func (s *scene) addLabel(str string, pos point, st state) point {
+	str = strings.Join(strings.Fields(str), " ")
+	if str == "" {
+		return pos
+	}
+	c, ok := parseColor(st.fill)
+	if !ok {
+		return pos
+	}
+	lb := label{
+		text:     str,
+		pos:      st.matrix.apply(pos),
+		size:     st.fontSize * st.matrix.scale(),
+		angle:    st.matrix.angle(),
+		anchor:   st.anchor,
+		baseline: st.baseline,
+		bold:     st.fontWeight == "bold" || st.fontWeight == "bolder",
+		fill:     withOpacity(c, st.fillOpacity*st.opacity),
+		clips:    st.clips,
+	}
+	s.items = append(s.items, &lb)
+	pos.X += float64(len([]rune(str))) * st.fontSize * glyphAdvance
+	return pos
+}
+
+func (s *scene) clipRegion(ref string, st state) (region, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || ref == "none" {
+		return nil, nil
+	}
+	ref = strings.TrimPrefix(ref, "url(")
+	ref = strings.TrimSuffix(ref, ")")
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "#")
+	n, ok := s.clips[ref]
+	if !ok {
+		return nil, nil
+	}
+	if v, ok := n.attrs["transform"]; ok {
+		mat, err := parseTransform(v)
+		if err != nil {
+			return nil, err
+		}
+		st.matrix = st.matrix.mul(mat)
+	}
+	var rg region
+	for _, c := range n.children {
+		if c.isText() {
+			continue
+		}
+		mat := st.matrix
+		if v, ok := c.attrs["transform"]; ok {
+			m, err := parseTransform(v)
+			if err != nil {
+				return nil, err
+			}
+			mat = mat.mul(m)
+		}
+		paths, err := geometry(c)
+		if err != nil {
+			return nil, err
+		}
+		rg = append(rg, mat.transform(paths)...)
+	}
+	if rg == nil {
+		rg = region{}
+	}
+	return rg, nil
+}
+
+func geometry(n *node) ([]subpath, error) {
+	get := func(name string) float64 {
+		return parseLength(n.attrs[name])
+	}
+	switch n.name {
+	case "path":
+		return parsePath(n.attrs["d"])
+	case "rect":
+		var (
+			x = get("x")
+			y = get("y")
+			w = get("width")
+			h = get("height")
+		)
+		if w <= 0 || h <= 0 {
+			return nil, nil
+		}
+		s := subpath{
+			closed: true,
+			points: []point{{X: x, Y: y}, {X: x + w, Y: y}, {X: x + w, Y: y + h}, {X: x, Y: y + h}},
+		}
+		return []subpath{s}, nil
+	case "circle":
+		r := get("r")
+		if r <= 0 {
+			return nil, nil
+		}
+		return []subpath{ellipse(get("cx"), get("cy"), r, r)}, nil
+	case "ellipse":
+		rx, ry := get("rx"), get("ry")
+		if rx <= 0 || ry <= 0 {
+			return nil, nil
+		}
+		return []subpath{ellipse(get("cx"), get("cy"), rx, ry)}, nil
+	case "line":
+		s := subpath{
+			points: []point{{X: get("x1"), Y: get("y1")}, {X: get("x2"), Y: get("y2")}},
+		}
+		return []subpath{s}, nil
+	case "polyline", "polygon":
+		var (
+			vs = parseNumbers(n.attrs["points"])
+			s  = subpath{closed: n.name == "polygon"}
+		)
+		for i := 0; i+1 < len(vs); i += 2 {
+			s.points = append(s.points, point{X: vs[i], Y: vs[i+1]})
+		}
+		if len(s.points) < 2 {
+			return nil, nil
+		}
+		return []subpath{s}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func parseLength(str string) float64 {
+	str = strings.TrimSpace(str)
+	for _, u := range []string{"px", "pt", "%"} {
+		str = strings.TrimSuffix(str, u)
+	}
+	f, _ := strconv.ParseFloat(str, 64)
+	return f
+}
+
+// parseOpacity accepts values given as a fraction or as a percentage. Values
+// out of range are clamped like browsers do.
+func parseOpacity(str string) float64 {
+	str = strings.TrimSpace(str)
+	if strings.HasSuffix(str, "%") {
+		return clamp(parseLength(str)/100, 0, 1)
+	}
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 1
+	}
+	return clamp(f, 0, 1)
+}
+
+func parseDashes(str string) []float64 {
+	if strings.TrimSpace(str) == "none" {
+		return nil
+	}
+	var (
+		list  = parseNumbers(str)
+		total float64
+	)
+	for _, f := range list {
+		total += f
+	}
+	if total <= 0 {
+		return nil
+	}
+	if len(list)%2 == 1 {
+		list = append(list, list...)
+	}
+	return list
+}
@@ -0,0 +1,176 @@
+package output
+
+import (
+	"math"
+)
+
+// strokePaths converts the outline of the given paths into filled polygons.
+// Every polygon has the same orientation so that overlapping parts are not
+// cancelled by the rasterizer. Joins are beveled.
+func strokePaths(paths []subpath, width float64, dashes []float64, offset float64, cap string) []subpath {
+	if len(dashes) > 0 {
+		paths = dashPaths(paths, dashes, offset)
+	}
+	var (
+		half = width / 2
+		list []subpath
+	)
+	for _, s := range paths {
+		pts := dedupe(s.points)
+		if len(pts) < 2 {
+			continue
+		}
+		closed := s.closed && len(pts) > 2
+		if closed {
+			pts = append(pts, pts[0])
+		}
+		for i := 1; i < len(pts); i++ {
+			var (
+				p0 = pts[i-1]
+				p1 = pts[i]
+				n  = normal(p0, p1).mul(half)
+			)
+			if !closed && cap == "square" {
+				dir := p1.sub(p0).mul(half / p1.sub(p0).length())
+				if i == 1 {
+					p0 = p0.sub(dir)
+				}
+				if i == len(pts)-1 {
+					p1 = p1.add(dir)
+				}
+			}
+			list = append(list, oriented(p0.add(n), p1.add(n), p1.sub(n), p0.sub(n)))
+		}
+		for i := 1; i < len(pts)-1; i++ {
+			list = append(list, joinPolygons(pts[i-1], pts[i], pts[i+1], half)...)
+		}
+		if closed {
+			list = append(list, joinPolygons(pts[len(pts)-2], pts[0], pts[1], half)...)
+		} else if cap == "round" {
+			list = append(list, oriented(ellipse(pts[0].X, pts[0].Y, half, half).points...))
+			end := pts[len(pts)-1]
+			list = append(list, oriented(ellipse(end.X, end.Y, half, half).points...))
+		}
+	}
+	return list
+}
+
+func joinPolygons(p0, p1, p2 point, half float64) []subpath {
+	var (
+		n0 = normal(p0, p1).mul(half)
+		n1 = normal(p1, p2).mul(half)
+	)
+	return []subpath{
+		oriented(p1, p1.add(n0), p1.add(n1)),
+		oriented(p1, p1.sub(n0), p1.sub(n1)),
+	}
+}
+
+func normal(p0, p1 point) point {
+	var (
+		d = p1.sub(p0)
+		n = d.length()
+	)
+	if n == 0 {
+		return point{}
+	}
+	return point{X: -d.Y / n, Y: d.X / n}
+}
+
+func oriented(points ...point) subpath {
+	var area float64
+	for i := range points {
+		j := (i + 1) % len(points)
+		area += points[i].X*points[j].Y - points[j].X*points[i].Y
+	}
+	if area < 0 {
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+	return subpath{
+		points: points,
+		closed: true,
+	}
+}
+
+func dedupe(points []point) []point {
+	list := make([]point, 0, len(points))
+	for i, p := range points {
+		if i > 0 && p == list[len(list)-1] {
+			continue
+		}
+		list = append(list, p)
+	}
+	return list
+}
+
+func dashPaths(paths []subpath, dashes []float64, offset float64) []subpath {
+	var (
+		total float64
+		list  []subpath
+	)
+	for _, d := range dashes {
+		total += d
+	}
+	if total <= 0 {
+		return paths
+	}
+	for _, s := range paths {
+		pts := s.points
+		if s.closed && len(pts) > 2 {
+			pts = append(append([]point{}, pts...), pts[0])
+		}
+		var (
+			index  int
+			remain = dashes[0]
+			on     = true
+			curr   subpath
+		)
+		for skip := math.Mod(offset, total); skip > 0; {
+			if skip < remain {
+				remain -= skip
+				break
+			}
+			skip -= remain
+			index = (index + 1) % len(dashes)
+			remain = dashes[index]
+			on = !on
+		}
+		if on && len(pts) > 0 {
+			curr.points = append(curr.points, pts[0])
+		}
+		for i := 1; i < len(pts); i++ {
+			var (
+				p0   = pts[i-1]
+				p1   = pts[i]
+				size = p1.sub(p0).length()
+				pos  float64
+			)
+			for size-pos > remain {
+				pos += remain
+				p := p0.add(p1.sub(p0).mul(pos / size))
+				if on {
+					curr.points = append(curr.points, p)
+					if len(curr.points) > 1 {
+						list = append(list, curr)
+					}
+					curr = subpath{}
+				} else {
+					curr.points = append(curr.points, p)
+				}
+				on = !on
+				index = (index + 1) % len(dashes)
+				remain = dashes[index]
+			}
+			remain -= size - pos
+			if on {
+				curr.points = append(curr.points, p1)
+			}
+		}
+		if on && len(curr.points) > 1 {
+			list = append(list, curr)
+		}
+	}
+	return list
+}
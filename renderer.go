@@ -3,6 +3,8 @@ package charts
 import (
 	"fmt"
 	"math"
+	"sort"
+	"strconv"
 
 	"github.com/midbel/slices"
 	"github.com/midbel/svg"
@@ -20,10 +22,16 @@ type Renderer[T, U ScalerConstraint] interface {
 	Render(Serie[T, U]) svg.Element
 }
 
+// PolarRenderer draws the points of a serie around a circle, one spoke per
+// point. PolarDefault draws a sector per point, PolarArea and PolarPolygon draw
+// the points as a filled or an outlined polygon (radar). Sub points are
+// stacked when Stacked is set or drawn as distinct layers otherwise, each layer
+// taking the next color of the fill list. Offset is the index of the first
+// color used, so that series drawn over each other get distinct colors. The
+// grid is not drawn when Ticks is zero.
 type PolarRenderer[T ~string, U ~float64] struct {
 	Style
 
-	Fill       []string
 	Radius     float64
 	Ticks      int
 	TicksStyle LineStyle
@@ -31,19 +39,29 @@ type PolarRenderer[T ~string, U ~float64] struct {
 	Stacked    bool
 	Angular    bool
 	Normalize  bool
+	Offset     int
 	Point      PointFunc
 }
 
 func (r PolarRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
-	var (
-		grp = classGroup("", "polar")
-		el  svg.Element
-	)
+	grp := classGroup("polar")
+	if len(serie.Points) == 0 {
+		return grp.AsElement()
+	}
+	if r.Radius <= 0 {
+		r.Radius = math.Min(serie.X.Max(), serie.Y.Max())/2 - r.labelWidth(serie)
+	}
 	grp.Transform = svg.Translate(serie.X.Max()/2, serie.Y.Max()/2)
-	grp.Append(r.drawTicks(serie))
-	if r.Stacked {
+	if r.Ticks > 0 {
+		grp.Append(r.drawTicks(serie))
+	}
+	var el svg.Element
+	switch {
+	case r.Type == PolarArea || r.Type == PolarPolygon:
+		el = r.drawArea(serie)
+	case r.Stacked:
 		el = r.drawStackedArcs(serie)
-	} else {
+	default:
 		el = r.drawArcs(serie)
 	}
 	if el != nil {
@@ -52,44 +70,42 @@ func (r PolarRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 	return grp.AsElement()
 }
 
+func (r PolarRenderer[T, U]) Swatch() Swatch {
+	sw := r.Style.Swatch()
+	sw.Fill = r.fill(0)
+	sw.LineColor = sw.Fill
+	return sw
+}
+
 func (r PolarRenderer[T, U]) drawStackedArcs(serie Serie[T, U]) svg.Element {
 	var (
-		angle = fullcircle / float64(len(serie.Points))
-		scale = serie.Y.replace(NewRange(0, r.Radius))
+		count = len(serie.Points)
+		scale = r.radiusScale(serie)
 		grp   svg.Group
 	)
 	for i, pt := range serie.Points {
 		var (
-			ori svg.Pos
 			add float64
-			pre float64
+			ag1 = polarAngle(i, count)
+			ag2 = polarAngle(i+1, count)
 		)
 		for j, p := range pt.Sub {
 			var (
-				pat  svg.Path
-				fill = svg.NewFill(r.Fill[j%len(r.Fill)])
-				y    float64
-				f    float64
-				ag1  = angle * float64(i) * deg2rad
-				ag2  = angle * float64(i+1) * deg2rad
+				pat = r.polarPath(r.fill(j))
+				f   = scale(U(add))
+				y   float64
 			)
-			pre = add
-			f = scale.Scale(U(pre))
-			add += any(p.Y).(float64)
-			y = scale.Scale(U(add))
-			pat.Fill = fill
-			pat.Fill.Opacity = 0.7
-			pat.Stroke = svg.NewStroke("white", 1)
-
-			pat.AbsMoveTo(ori)
+			add += float64(p.Y)
+			y = scale(U(add))
+
+			pat.AbsMoveTo(getPosFromAngle(ag1, f))
 			pat.AbsLineTo(getPosFromAngle(ag1, y))
 			pat.AbsArcTo(getPosFromAngle(ag2, y), y, y, 0, false, true)
 			pat.AbsLineTo(getPosFromAngle(ag2, f))
-			pat.AbsArcTo(ori, f, f, 0, false, false)
+			pat.AbsArcTo(getPosFromAngle(ag1, f), f, f, 0, false, false)
 			pat.ClosePath()
 
-			ori = getPosFromAngle(ag1, y)
-
+			pat.Data = serie.subData(pt, p)
 			grp.Append(pat.AsElement())
 		}
 	}
@@ -98,26 +114,23 @@ func (r PolarRenderer[T, U]) drawStackedArcs(serie Serie[T, U]) svg.Element {
 
 func (r PolarRenderer[T, U]) drawArcs(serie Serie[T, U]) svg.Element {
 	var (
-		angle = fullcircle / float64(len(serie.Points))
-		scale = serie.Y.replace(NewRange(0, r.Radius))
+		count = len(serie.Points)
+		scale = r.radiusScale(serie)
 		grp   svg.Group
 	)
 	for i, pt := range serie.Points {
 		var (
-			pat  svg.Path
-			fill = svg.NewFill(r.Fill[i%len(r.Fill)])
-			ag1  = angle * float64(i) * deg2rad
-			ag2  = angle * float64(i+1) * deg2rad
-			y    = scale.Scale(pt.Y)
+			pat = r.polarPath(r.fill(i))
+			ag1 = polarAngle(i, count)
+			ag2 = polarAngle(i+1, count)
+			y   = scale(pt.Y)
 		)
-		pat.Fill = fill
-		pat.Fill.Opacity = 0.9
-		pat.Stroke = svg.NewStroke("white", 2)
 		pat.AbsMoveTo(svg.NewPos(0, 0))
 		pat.AbsLineTo(getPosFromAngle(ag1, y))
 		pat.AbsArcTo(getPosFromAngle(ag2, y), y, y, 0, false, true)
 		pat.AbsLineTo(svg.NewPos(0, 0))
 		pat.ClosePath()
+		pat.Data = serie.pointData(pt)
 		grp.Append(pat.AsElement())
 	}
 	return grp.AsElement()
@@ -125,36 +138,125 @@ func (r PolarRenderer[T, U]) drawArcs(serie Serie[T, U]) svg.Element {
 
 func (r PolarRenderer[T, U]) drawArea(serie Serie[T, U]) svg.Element {
 	var (
-		angle = fullcircle / float64(len(serie.Points))
-		scale = serie.Y.replace(NewRange(0, r.Radius))
-		pg    svg.Polygon
-		grp   svg.Group
+		count  = len(serie.Points)
+		scale  = r.radiusScale(serie)
+		layers = r.polarLayers(serie)
+		grp    = classGroup("radar")
+		marks  svg.Group
 	)
-	pg.Fill = svg.NewFill("blue")
-	pg.Fill.Opacity = 0.4
-	pg.Stroke = svg.NewStroke("blue", 1.5)
-
-	for i, pt := range serie.Points {
+	// outer layers first to keep the inner ones visible when stacked
+	for j := len(layers) - 1; j >= 0; j-- {
 		var (
-			ag  = angle * float64(i) * deg2rad
-			pos = getPosFromAngle(ag, scale.Scale(pt.Y))
+			pg   svg.Polygon
+			fill = r.fill(j)
 		)
-		if r.Point != nil {
-			grp.Append(r.Point(pos))
+		pg.Fill = svg.NewFill(ColorNone)
+		if r.Type == PolarArea {
+			pg.Fill = svg.NewFill(fill)
+			pg.Fill.Opacity = r.FillOpacity
+		}
+		pg.Stroke = svg.NewStroke(fill, r.LineWidth)
+		pg.Stroke.Opacity = r.LineOpacity
+		for i, v := range layers[j] {
+			var (
+				pt  = serie.Points[i]
+				pos = getPosFromAngle(polarAngle(i, count), scale(U(v)))
+			)
+			pg.Points = append(pg.Points, pos)
+			if r.Point == nil {
+				continue
+			}
+			el := r.Point(pos, DefaultSize, fill)
+			if data := serie.pointData(pt); len(data) > 0 {
+				g := classGroup("point")
+				g.Data = data
+				if j < len(pt.Sub) {
+					g.Data = serie.subData(pt, pt.Sub[j])
+				}
+				g.Append(el)
+				el = g.AsElement()
+			}
+			marks.Append(el)
 		}
-		pg.Points = append(pg.Points, pos)
+		grp.Append(pg.AsElement())
 	}
-	grp.Append(pg.AsElement())
+	grp.Append(marks.AsElement())
 	return grp.AsElement()
 }
 
+// radiusScale gives the distance from the center of the values of a serie. The
+// y scaler of a serie is usually reversed to draw its values from the bottom
+// of the chart.
+func (r PolarRenderer[T, U]) radiusScale(serie Serie[T, U]) func(U) float64 {
+	var (
+		scale    = serie.Y.replace(NewRange(0, r.Radius))
+		vs       = scale.Values(1)
+		reversed = len(vs) > 1 && vs[0] > vs[len(vs)-1]
+	)
+	return func(v U) float64 {
+		if reversed {
+			return r.Radius - scale.Scale(v)
+		}
+		return scale.Scale(v)
+	}
+}
+
+// polarLayers gives the values of the polygons drawn for a serie: one per sub
+// point, cumulated when stacked, or the values of the points when they have
+// no sub points.
+func (r PolarRenderer[T, U]) polarLayers(serie Serie[T, U]) [][]float64 {
+	var depth int
+	for _, pt := range serie.Points {
+		if n := len(pt.Sub); n > depth {
+			depth = n
+		}
+	}
+	if depth == 0 {
+		values := make([]float64, len(serie.Points))
+		for i, pt := range serie.Points {
+			values[i] = float64(pt.Y)
+		}
+		return [][]float64{values}
+	}
+	layers := make([][]float64, depth)
+	for j := range layers {
+		layers[j] = make([]float64, len(serie.Points))
+		for i, pt := range serie.Points {
+			var v float64
+			if j < len(pt.Sub) {
+				v = float64(pt.Sub[j].Y)
+			}
+			if r.Stacked && j > 0 {
+				v += layers[j-1][i]
+			}
+			layers[j][i] = v
+		}
+	}
+	return layers
+}
+
+func (r PolarRenderer[T, U]) polarPath(fill string) svg.Path {
+	var pat svg.Path
+	pat.Fill = svg.NewFill(fill)
+	pat.Fill.Opacity = r.FillOpacity
+	pat.Stroke = svg.NewStroke("white", 1)
+	return pat
+}
+
+func (r PolarRenderer[T, U]) fill(i int) string {
+	if len(r.FillList) == 0 {
+		return r.LineColor
+	}
+	return r.FillList[(i+r.Offset)%len(r.FillList)]
+}
+
 func (r PolarRenderer[T, U]) drawTicks(serie Serie[T, U]) svg.Element {
 	var (
-		grp   svg.Group
-		angle = fullcircle / float64(len(serie.Points))
+		grp   = classGroup("polar-grid")
+		count = len(serie.Points)
 		step  = r.Radius / float64(r.Ticks)
 		sk    = svg.NewStroke("black", 1)
-		defs  svg.Defs
+		texts svg.Group
 	)
 	sk.Opacity = 0.25
 	switch r.TicksStyle {
@@ -164,53 +266,75 @@ func (r PolarRenderer[T, U]) drawTicks(serie Serie[T, U]) svg.Element {
 	case StyleDashed:
 		sk.DashArray = append(sk.DashArray, 10, 5)
 	}
-	grp.Append(defs.AsElement())
-	for i := 0; i < len(serie.Points); i++ {
+	for i := 0; i < count; i++ {
 		var (
-			id  = fmt.Sprintf("polar-tick-pat-%03d", i)
-			ag  = angle * float64(i) * deg2rad
+			ag  = polarAngle(i, count)
 			pos = getPosFromAngle(ag, r.Radius)
 			li  = svg.NewLine(svg.NewPos(0, 0), pos)
-			txt = svg.NewTextPath(any(serie.Points[i].X).(string), id)
-			pat svg.Path
+			str = any(serie.Points[i].X).(string)
 		)
-
-		pat.Id = id
-		pat.Stroke = svg.NewStroke("green", 2)
-		pat.AbsMoveTo(pos)
-		pat.AbsArcTo(getPosFromAngle(angle*float64(i+1)*deg2rad, r.Radius), r.Radius, r.Radius, 0, false, true)
-
-		defs.Append(pat.AsElement())
-
-		txt.Font = svg.NewFont(FontSize)
-		txt.Shift = svg.NewPos(FontSize*0.5, -FontSize*0.5)
-		grp.Append(txt.AsElement())
-
 		li.Stroke = sk
 		grp.Append(li.AsElement())
+
+		if r.Type != PolarArea && r.Type != PolarPolygon {
+			// sectors are labeled at their middle
+			ag = (polarAngle(i, count) + polarAngle(i+1, count)) / 2
+		}
+		texts.Append(r.spokeLabel(str, ag))
 	}
-	for i := step; i <= r.Radius; i += step {
+	for i := 1; i <= r.Ticks; i++ {
+		radius := step * float64(i)
 		if r.Angular {
-			c := r.drawAngularTicks(len(serie.Points), i, sk)
-			grp.Append(c)
+			grp.Append(r.drawAngularTicks(count, radius, sk))
 		} else {
-			c := r.drawCircularTicks(float64(i), sk)
-			grp.Append(c)
+			grp.Append(r.drawCircularTicks(radius, sk))
 		}
 	}
+	grp.Append(texts.AsElement())
 	return grp.AsElement()
 }
 
-func (r PolarRenderer[T, U]) drawAngularTicks(n int, radius float64, stroke svg.Stroke) svg.Element {
+// labelWidth gives the room needed around the circle by the labels of the
+// points.
+func (r PolarRenderer[T, U]) labelWidth(serie Serie[T, U]) float64 {
+	width := FontSize * 2
+	for _, pt := range serie.Points {
+		w := textWidth(any(pt.X).(string), FontSize) + FontSize
+		width = math.Max(width, w)
+	}
+	return width
+}
+
+func (r PolarRenderer[T, U]) spokeLabel(str string, angle float64) svg.Element {
 	var (
-		pg svg.Polygon
-		ag = fullcircle / float64(n)
+		pos = getPosFromAngle(angle, r.Radius+FontSize*0.5)
+		txt = newText(str)
 	)
+	txt.Pos = pos
+	txt.Font = svg.NewFont(FontSize)
+	txt.Baseline = "middle"
+	switch cos := math.Cos(angle); {
+	case cos > 0.1:
+		txt.Anchor = "start"
+	case cos < -0.1:
+		txt.Anchor = "end"
+	default:
+		txt.Anchor = "middle"
+		if math.Sin(angle) < 0 {
+			txt.Baseline = "auto"
+		} else {
+			txt.Baseline = "hanging"
+		}
+	}
+	return txt.AsElement()
+}
+
+func (r PolarRenderer[T, U]) drawAngularTicks(n int, radius float64, stroke svg.Stroke) svg.Element {
+	var pg svg.Polygon
 	pg.Stroke = stroke
 	pg.Fill = svg.NewFill("none")
 	for i := 0; i < n; i++ {
-		a := ag * float64(i) * deg2rad
-		pg.Points = append(pg.Points, getPosFromAngle(a, radius))
+		pg.Points = append(pg.Points, getPosFromAngle(polarAngle(i, n), radius))
 	}
 	return pg.AsElement()
 }
@@ -223,6 +347,12 @@ func (r PolarRenderer[T, U]) drawCircularTicks(radius float64, stroke svg.Stroke
 	return ci.AsElement()
 }
 
+// polarAngle gives the angle (in radians) of the i-th of n spokes, the first
+// one being at the top of the circle.
+func polarAngle(i, n int) float64 {
+	return (fullcircle*float64(i)/float64(n) - fullcircle/4) * deg2rad
+}
+
 type SunburstRenderer[T ~string, U ~float64] struct {
 	Style
 	InnerRadius float64
@@ -248,7 +378,7 @@ func (r SunburstRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 	for _, pt := range serie.Points {
 		var grp svg.Group
 		grp.Id = fmt.Sprintf("%v", pt.X)
-		angle += r.renderPoint(&grp, pt, angle, 0)
+		angle += r.renderPoint(&grp, serie, pt, angle, 0)
 
 		sun.Append(grp.AsElement())
 		r.FillList.Next()
@@ -256,7 +386,7 @@ func (r SunburstRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 	return sun.AsElement()
 }
 
-func (r SunburstRenderer[T, U]) renderPoint(grp *svg.Group, pt Point[T, U], offset, level float64) float64 {
+func (r SunburstRenderer[T, U]) renderPoint(grp *svg.Group, serie Serie[T, U], pt Point[T, U], offset, level float64) float64 {
 	var (
 		value    = r.scaler.Scale(pt.Y)
 		distance = r.distanceFromCenter() + (r.height * level) + r.height
@@ -267,6 +397,7 @@ func (r SunburstRenderer[T, U]) renderPoint(grp *svg.Group, pt Point[T, U], offs
 		pat      = r.currPath()
 	)
 	pat.Id = fmt.Sprintf("%v", pt.X)
+	pat.Data = serie.pointData(pt)
 
 	pat.AbsMoveTo(pos1)
 	pat.AbsArcTo(pos2, distance, distance, 0, value > halfcircle, true)
@@ -281,7 +412,7 @@ func (r SunburstRenderer[T, U]) renderPoint(grp *svg.Group, pt Point[T, U], offs
 		return value
 	}
 	for _, pt := range pt.Sub {
-		offset += r.renderPoint(grp, pt, offset, level)
+		offset += r.renderPoint(grp, serie, pt, offset, level)
 	}
 	return value
 }
@@ -293,11 +424,325 @@ func (r SunburstRenderer[T, U]) distanceFromCenter() float64 {
 	return r.InnerRadius
 }
 
+type TreemapLayout int
+
+const (
+	TreemapSquarify TreemapLayout = iota
+	TreemapSliceDice
+	TreemapBinary
+)
+
+// TreemapRenderer draws the hierarchy of the serie as nested rectangles with
+// an area proportional to their value. The padding of the style is kept
+// between a node and its children and its top padding gives the room for the
+// label of the node. Each top level node takes the next color of the style.
+type TreemapRenderer[T ~string, U ~float64] struct {
+	Style
+	Layout TreemapLayout
+}
+
+func (r TreemapRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	var (
+		grp   = classGroup("treemap")
+		area  = treeRect{W: serie.X.Max(), H: serie.Y.Max()}
+		rects = r.layout(r.weights(serie.Points), area, 0)
+	)
+	for i, pt := range serie.Points {
+		var g svg.Group
+		g.Id = fmt.Sprintf("%v", pt.X)
+		r.renderNode(&g, serie, pt, rects[i], r.FillList.Next(), 0)
+		grp.Append(g.AsElement())
+	}
+	return grp.AsElement()
+}
+
+func (r TreemapRenderer[T, U]) renderNode(grp *svg.Group, serie Serie[T, U], pt Point[T, U], area treeRect, fill string, depth int) {
+	if area.W <= 0 || area.H <= 0 {
+		return
+	}
+	var rec svg.Rect
+	rec.Pos = svg.NewPos(area.X, area.Y)
+	rec.Dim = svg.NewDim(area.W, area.H)
+	rec.Fill = svg.NewFill(fill)
+	rec.Fill.Opacity = r.FillOpacity
+	if r.LineColor != "" && r.LineWidth > 0 {
+		rec.Stroke = svg.NewStroke(r.LineColor, r.LineWidth)
+		rec.Stroke.Opacity = r.LineOpacity
+	}
+	if !pt.isLeaf() {
+		rec.Fill.Opacity *= 0.6
+		pt.Y = U(r.weight(pt))
+	}
+	rec.Data = serie.pointData(pt)
+	grp.Append(rec.AsElement())
+
+	var (
+		label  = fmt.Sprintf("%v", pt.X)
+		margin = r.FontSize * 0.3
+	)
+	if pt.isLeaf() {
+		pos := svg.NewPos(area.X+margin, area.Y+margin+r.FontSize/2)
+		if txt, ok := r.label(label, pos, area.W-2*margin, area.H-2*margin); ok {
+			grp.Append(txt.AsElement())
+		}
+		return
+	}
+	pos := svg.NewPos(area.X+margin, area.Y+r.Padding.Top/2)
+	if txt, ok := r.label(label, pos, area.W-2*margin, math.Min(r.Padding.Top, area.H)); ok {
+		grp.Append(txt.AsElement())
+	}
+	rects := r.layout(r.weights(pt.Sub), area.inset(r.Padding), depth+1)
+	for i := range pt.Sub {
+		r.renderNode(grp, serie, pt.Sub[i], rects[i], fill, depth+1)
+	}
+}
+
+// label gives the text of a node shortened to the available width. No text is
+// given when the room is too small to have something readable.
+func (r TreemapRenderer[T, U]) label(str string, pos svg.Pos, width, height float64) (svg.Text, bool) {
+	if height < r.FontSize {
+		return svg.Text{}, false
+	}
+	if textWidth(str, r.FontSize) > width {
+		n := int(width/textWidth("x", r.FontSize)) - 1
+		if n < 1 {
+			return svg.Text{}, false
+		}
+		str = string([]rune(str)[:n]) + "…"
+	}
+	txt := r.Text(str)
+	txt.Pos = pos
+	return txt, true
+}
+
+func (r TreemapRenderer[T, U]) layout(values []float64, area treeRect, depth int) []treeRect {
+	switch r.Layout {
+	case TreemapSliceDice:
+		return sliceDiceLayout(values, area, depth%2 == 0)
+	case TreemapBinary:
+		return binaryLayout(values, area)
+	default:
+		return squarifyLayout(values, area)
+	}
+}
+
+func (r TreemapRenderer[T, U]) weights(points []Point[T, U]) []float64 {
+	var list []float64
+	for i := range points {
+		list = append(list, r.weight(points[i]))
+	}
+	return list
+}
+
+// weight gives the value of a leaf or the sum of the values of the leaves
+// below a node. Missing and negative values count for nothing.
+func (r TreemapRenderer[T, U]) weight(pt Point[T, U]) float64 {
+	if pt.isLeaf() {
+		v := float64(pt.Y)
+		if math.IsNaN(v) || v < 0 {
+			return 0
+		}
+		return v
+	}
+	var sum float64
+	for i := range pt.Sub {
+		sum += r.weight(pt.Sub[i])
+	}
+	return sum
+}
+
+type treeRect struct {
+	X, Y float64
+	W, H float64
+}
+
+func (t treeRect) inset(pad Padding) treeRect {
+	t.X += pad.Left
+	t.Y += pad.Top
+	t.W = math.Max(0, t.W-pad.Horizontal())
+	t.H = math.Max(0, t.H-pad.Vertical())
+	return t
+}
+
+// squarifyLayout places the values by rows, a value being added to the current
+// row as long as it does not degrade the worst aspect ratio of the row.
+func squarifyLayout(values []float64, area treeRect) []treeRect {
+	var (
+		rects = make([]treeRect, len(values))
+		total = sumValues(values)
+		index []int
+	)
+	if total <= 0 || area.W <= 0 || area.H <= 0 {
+		return rects
+	}
+	for i := range values {
+		if values[i] > 0 {
+			index = append(index, i)
+		}
+	}
+	sort.SliceStable(index, func(i, j int) bool {
+		return values[index[i]] > values[index[j]]
+	})
+	var (
+		scale = area.W * area.H / total
+		row   []int
+		sizes []float64
+	)
+	for k := 0; k < len(index); {
+		var (
+			curr = values[index[k]] * scale
+			side = math.Min(area.W, area.H)
+		)
+		if len(row) == 0 || worstRatio(append(sizes, curr), side) <= worstRatio(sizes, side) {
+			row = append(row, index[k])
+			sizes = append(sizes, curr)
+			k++
+			continue
+		}
+		area = layoutRow(row, sizes, area, rects)
+		row, sizes = row[:0], sizes[:0]
+	}
+	layoutRow(row, sizes, area, rects)
+	return rects
+}
+
+func worstRatio(sizes []float64, side float64) float64 {
+	var (
+		sum = sumValues(sizes)
+		min = math.Inf(1)
+		max = math.Inf(-1)
+	)
+	for _, s := range sizes {
+		min = math.Min(min, s)
+		max = math.Max(max, s)
+	}
+	side *= side
+	return math.Max(side*max/(sum*sum), (sum*sum)/(side*min))
+}
+
+// layoutRow places the sizes along the shortest side of the area and gives
+// the area remaining for the next rows.
+func layoutRow(row []int, sizes []float64, area treeRect, rects []treeRect) treeRect {
+	sum := sumValues(sizes)
+	if len(row) == 0 || sum <= 0 {
+		return area
+	}
+	if area.W >= area.H {
+		var (
+			width = sum / area.H
+			y     = area.Y
+		)
+		for j, i := range row {
+			height := sizes[j] / width
+			rects[i] = treeRect{X: area.X, Y: y, W: width, H: height}
+			y += height
+		}
+		area.X += width
+		area.W = math.Max(0, area.W-width)
+	} else {
+		var (
+			height = sum / area.W
+			x      = area.X
+		)
+		for j, i := range row {
+			width := sizes[j] / height
+			rects[i] = treeRect{X: x, Y: area.Y, W: width, H: height}
+			x += width
+		}
+		area.Y += height
+		area.H = math.Max(0, area.H-height)
+	}
+	return area
+}
+
+// sliceDiceLayout splits the area along its width or its height in the order
+// of the values. The direction is alternated at each level of the hierarchy.
+func sliceDiceLayout(values []float64, area treeRect, horizontal bool) []treeRect {
+	var (
+		rects  = make([]treeRect, len(values))
+		total  = sumValues(values)
+		offset float64
+	)
+	if total <= 0 {
+		return rects
+	}
+	for i, v := range values {
+		part := math.Max(0, v) / total
+		if horizontal {
+			rects[i] = treeRect{X: area.X + offset, Y: area.Y, W: area.W * part, H: area.H}
+			offset += area.W * part
+		} else {
+			rects[i] = treeRect{X: area.X, Y: area.Y + offset, W: area.W, H: area.H * part}
+			offset += area.H * part
+		}
+	}
+	return rects
+}
+
+// binaryLayout splits recursively the values in two groups of sums as close
+// as possible and the area along its longest side between these groups.
+func binaryLayout(values []float64, area treeRect) []treeRect {
+	rects := make([]treeRect, len(values))
+	binarySplit(values, rects, area)
+	return rects
+}
+
+func binarySplit(values []float64, rects []treeRect, area treeRect) {
+	if len(values) == 0 {
+		return
+	}
+	total := sumValues(values)
+	if len(values) == 1 || total <= 0 {
+		if total > 0 {
+			rects[0] = area
+		}
+		return
+	}
+	var (
+		mid  = 1
+		acc  float64
+		best = math.Inf(1)
+		left float64
+	)
+	for i := 0; i < len(values)-1; i++ {
+		acc += math.Max(0, values[i])
+		if diff := math.Abs(total - 2*acc); diff < best {
+			best, mid, left = diff, i+1, acc
+		}
+	}
+	var (
+		fst   = area
+		lst   = area
+		ratio = left / total
+	)
+	if area.W >= area.H {
+		fst.W = area.W * ratio
+		lst.X += fst.W
+		lst.W -= fst.W
+	} else {
+		fst.H = area.H * ratio
+		lst.Y += fst.H
+		lst.H -= fst.H
+	}
+	binarySplit(values[:mid], rects[:mid], fst)
+	binarySplit(values[mid:], rects[mid:], lst)
+}
+
+func sumValues(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		if v > 0 {
+			sum += v
+		}
+	}
+	return sum
+}
+
 type PieRenderer[T ~string, U ~float64] struct {
 	Style
 	InnerRadius float64
 	OuterRadius float64
-	Text        TextPosition
+	Labels      Labels
 
 	scaler ringScaler[U]
 }
@@ -309,8 +754,10 @@ func (r PieRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 	r.scaler = ring[U](serie.Sum())
 
 	var (
-		grp   = classGroup("pie")
-		angle float64
+		grp    = classGroup("pie")
+		angle  float64
+		labels []label
+		leads  [][]svg.Pos
 	)
 	grp.Transform = svg.Translate(serie.X.Max()/2, serie.Y.Max()/2)
 	for _, pt := range serie.Points {
@@ -328,13 +775,105 @@ func (r PieRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 		pat.AbsArcTo(pos4, r.difference(), r.difference(), 0, val > halfcircle, false)
 		pat.AbsLineTo(r.getPos1(rad))
 		pat.ClosePath()
+		pat.Data = serie.pointData(pt)
 		grp.Append(pat.AsElement())
 
+		if r.Labels.Visible() {
+			lb, lead := r.sliceLabel(pt, (angle+val/2)*deg2rad)
+			labels = append(labels, lb)
+			leads = append(leads, lead)
+		}
 		angle += val
 	}
+	if len(labels) > 0 {
+		grp.Append(r.drawSliceLabels(labels, leads))
+	}
+	return grp.AsElement()
+}
+
+// sliceLabel places the label of a slice at its centroid or outside of the
+// pie. Outside labels are linked to their slice by a leader line starting at
+// the edge of the slice.
+func (r PieRenderer[T, U]) sliceLabel(pt Point[T, U], angle float64) (label, []svg.Pos) {
+	lb := label{
+		Text:   r.Labels.format(float64(pt.Y)),
+		Anchor: "middle",
+	}
+	if r.Labels.Position != LabelOutside {
+		lb.Pos = getPosFromAngle(angle, (r.OuterRadius+r.difference())/2)
+		return lb, nil
+	}
+	var (
+		edge  = getPosFromAngle(angle, r.OuterRadius)
+		elbow = getPosFromAngle(angle, r.OuterRadius+r.FontSize)
+	)
+	lb.Pos = elbow
+	if math.Cos(angle) >= 0 {
+		lb.Pos.X += r.FontSize
+		lb.Anchor = "start"
+	} else {
+		lb.Pos.X -= r.FontSize
+		lb.Anchor = "end"
+	}
+	return lb, []svg.Pos{edge, elbow}
+}
+
+// drawSliceLabels spreads the labels on each side of the pie before drawing
+// them with their leader lines.
+func (r PieRenderer[T, U]) drawSliceLabels(labels []label, leads [][]svg.Pos) svg.Element {
+	if r.Labels.Position != LabelOutside {
+		spreadLabels(labels, r.FontSize, 0)
+		return r.drawLabels(labels)
+	}
+	var left, right []int
+	for i := range labels {
+		if labels[i].Anchor == "end" {
+			left = append(left, i)
+		} else {
+			right = append(right, i)
+		}
+	}
+	for _, side := range [][]int{left, right} {
+		list := make([]label, len(side))
+		for j, i := range side {
+			list[j] = labels[i]
+		}
+		spreadLabels(list, r.FontSize, math.Inf(1))
+		for j, i := range side {
+			labels[i] = list[j]
+		}
+	}
+	grp := classGroup("leaders")
+	for i, lb := range labels {
+		var (
+			pat  = r.leaderPath()
+			lead = leads[i]
+			end  = lb.Pos
+		)
+		if lb.Anchor == "end" {
+			end.X += r.FontSize * 0.2
+		} else {
+			end.X -= r.FontSize * 0.2
+		}
+		pat.AbsMoveTo(lead[0])
+		pat.AbsLineTo(svg.NewPos(lead[1].X, end.Y))
+		pat.AbsLineTo(end)
+		grp.Append(pat.AsElement())
+	}
+	grp.Append(r.drawLabels(labels))
 	return grp.AsElement()
 }
 
+// leaderPath gives the path of a leader line. It is drawn with the color of
+// the text since the line color of a pie is often the one of the background.
+func (r PieRenderer[T, U]) leaderPath() svg.Path {
+	style := r.Style
+	style.LineColor = r.FontColor
+	style.LineWidth = 1
+	style.LineType = StyleSolid
+	return style.linePath()
+}
+
 func (r PieRenderer[T, U]) getPos4(rad float64) svg.Pos {
 	return getPosFromAngle(rad, r.difference())
 }
@@ -357,7 +896,8 @@ func (r PieRenderer[T, U]) difference() float64 {
 
 type GroupRenderer[T ~string, U float64] struct {
 	Style
-	Width float64
+	Width  float64
+	Labels Labels
 }
 
 func (r GroupRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
@@ -365,9 +905,10 @@ func (r GroupRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 		r.Width = 1
 	}
 	var (
-		pal = r.FillList.Clone()
-		grp = classGroup("bar")
-		sub = serie.X.replace(NewRange(0, serie.X.Space()))
+		pal    = r.FillList.Clone()
+		grp    = classGroup("bar")
+		sub    = serie.X.replace(NewRange(0, serie.X.Space()))
+		labels []label
 	)
 	for _, pt := range serie.Points {
 		r.FillList = pal.Clone()
@@ -389,17 +930,30 @@ func (r GroupRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 				rec    = r.Rect(width, height)
 			)
 			rec.Pos = svg.NewPos(sub.Scale(s.X)+offset, serie.Y.Scale(s.Y))
+			rec.Data = serie.subData(pt, s)
 			g.Append(rec.AsElement())
+			if r.Labels.Visible() {
+				pos := svg.NewPos(rec.Pos.X+serie.X.Scale(pt.X), rec.Pos.Y)
+				labels = append(labels, barLabel(r.Labels, r.FontSize, float64(s.Y), pos, width, height))
+			}
 		}
 		grp.Append(g.AsElement())
 	}
+	if len(labels) > 0 {
+		spreadLabels(labels, r.FontSize, 0)
+		grp.Append(r.drawLabels(labels))
+	}
 	return grp.AsElement()
 }
 
+// StackedRenderer draws the sub points of each point on top of each other.
+// Labels are written on each part of the bars or, when outside, give the
+// total of the bars above them.
 type StackedRenderer[T ~string, U ~float64] struct {
 	Style
 	Width     float64
 	Normalize bool
+	Labels    Labels
 }
 
 func (r StackedRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
@@ -407,10 +961,11 @@ func (r StackedRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 		r.Width = 1
 	}
 	var (
-		grp  svg.Group
-		pal  = r.FillList.Clone()
-		max  = serie.Y.Max()
-		size = serie.X.Space()
+		grp    svg.Group
+		pal    = r.FillList.Clone()
+		max    = serie.Y.Max()
+		size   = serie.X.Space()
+		labels []label
 	)
 	for _, parent := range serie.Points {
 		r.FillList = pal.Clone()
@@ -420,6 +975,10 @@ func (r StackedRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 		)
 		bar.Transform = svg.Translate(serie.X.Scale(parent.X), 0)
 		for _, pt := range parent.Sub {
+			var (
+				data  = serie.subData(parent, pt)
+				value = float64(pt.Y)
+			)
 			if r.Normalize {
 				pt.Y = pt.Y / parent.Y
 			}
@@ -430,25 +989,46 @@ func (r StackedRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 				rec = r.Rect(wid, max-val)
 			)
 			rec.Pos = svg.NewPos(off, val-offset)
+			rec.Data = data
 			bar.Append(rec.AsElement())
+			if r.Labels.Visible() && r.Labels.Position != LabelOutside {
+				pos := svg.NewPos(rec.Pos.X+serie.X.Scale(parent.X), rec.Pos.Y)
+				labels = append(labels, barLabel(r.Labels, r.FontSize, value, pos, wid, max-val))
+			}
 
 			offset += max - val
 		}
+		if r.Labels.Visible() && r.Labels.Position == LabelOutside {
+			var (
+				wid = size * r.Width
+				pos = svg.NewPos(serie.X.Scale(parent.X)+(size-wid)/2, max-offset)
+			)
+			labels = append(labels, barLabel(r.Labels, r.FontSize, float64(parent.Y), pos, wid, offset))
+		}
 		grp.Append(bar.AsElement())
 	}
+	if len(labels) > 0 {
+		spreadLabels(labels, r.FontSize, 0)
+		grp.Append(r.drawLabels(labels))
+	}
 	return grp.AsElement()
 }
 
 type BarRenderer[T ~string, U ~float64] struct {
 	Style
-	Width float64
+	Width  float64
+	Error  ErrorStyle
+	Labels Labels
 }
 
 func (r BarRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 	if r.Width <= 0 {
 		r.Width = 1
 	}
-	grp := classGroup("bar")
+	var (
+		grp    = classGroup("bar")
+		labels []label
+	)
 	for _, pt := range serie.Points {
 		var (
 			width  = serie.X.Space() * r.Width
@@ -457,82 +1037,350 @@ func (r BarRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 			rec    = r.Rect(width, height)
 		)
 		rec.Pos = svg.NewPos(serie.X.Scale(pt.X)+offset, serie.Y.Scale(pt.Y))
+		rec.Data = serie.pointData(pt)
 		grp.Append(rec.AsElement())
+		if r.Labels.Visible() {
+			labels = append(labels, barLabel(r.Labels, r.FontSize, float64(pt.Y), rec.Pos, width, height))
+		}
+	}
+	if r.Error.Visible {
+		x := serie.X.Space() / 2
+		grp.Append(drawWhiskers(serie, r.Error.withDefaults(r.LineColor), x))
+	}
+	if len(labels) > 0 {
+		spreadLabels(labels, r.FontSize, 0)
+		grp.Append(r.drawLabels(labels))
 	}
 	return grp.AsElement()
 }
 
-type PointRenderer[T, U ScalerConstraint] struct {
-	Fill  string
-	Point PointFunc
+// barLabel gives the label of the value of a vertical bar whose top left
+// corner is at pos.
+func barLabel(labels Labels, size, value float64, pos svg.Pos, width, height float64) label {
+	lb := label{
+		Text:   labels.format(value),
+		Pos:    svg.NewPos(pos.X+width/2, pos.Y),
+		Anchor: "middle",
+	}
+	switch labels.Position {
+	case LabelOutside:
+		lb.Pos.Y -= size * 0.8
+	case LabelEnd:
+		lb.Pos.Y += size * 0.8
+	default:
+		lb.Pos.Y += height / 2
+	}
+	return lb
 }
 
-func (r PointRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
-	if r.Point == nil {
-		r.Point = GetCircle
+// HorizontalBarRenderer draws the bars of a serie having its categories on
+// the y axis.
+type HorizontalBarRenderer[T ~float64, U ~string] struct {
+	Style
+	Width  float64
+	Error  ErrorStyle
+	Labels Labels
+}
+
+func (r HorizontalBarRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	if r.Width <= 0 {
+		r.Width = 1
 	}
-	grp := classGroup("scatter")
+	var (
+		grp    = classGroup("bar", "bar-horizontal")
+		labels []label
+	)
 	for _, pt := range serie.Points {
 		var (
-			x = serie.X.Scale(pt.X)
-			y = serie.Y.Scale(pt.Y)
+			height = serie.Y.Space() * r.Width
+			width  = serie.X.Scale(pt.X) - serie.X.Min()
+			offset = (serie.Y.Space() - height) / 2
+			rec    = r.Rect(width, height)
 		)
-		grp.Append(r.Point(svg.NewPos(x, y)))
+		rec.Pos = svg.NewPos(serie.X.Min(), serie.Y.Scale(pt.Y)+offset)
+		rec.Data = serie.pointData(pt)
+		grp.Append(rec.AsElement())
+		if r.Labels.Visible() {
+			labels = append(labels, horizontalBarLabel(r.Labels, r.FontSize, float64(pt.X), rec.Pos, width, height))
+		}
+	}
+	if r.Error.Visible {
+		y := serie.Y.Space() / 2
+		grp.Append(drawHorizontalWhiskers(serie, r.Error.withDefaults(r.LineColor), y))
+	}
+	if len(labels) > 0 {
+		spreadLabels(labels, r.FontSize, 0)
+		grp.Append(r.drawLabels(labels))
 	}
 	return grp.AsElement()
 }
 
-// type CubicRenderer[T, U ScalerConstraint] struct {
-// 	Style
-// 	Stretch float64
-// 	Fill    bool
-// 	Point   PointFunc
-// }
-
-// func (r CubicRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
-// 	var (
-// 		grp = classGroup("line")
-// 		pat = r.Path()
-// 		pos = svg.NewPos(serie.X.Min(), serie.Y.Max())
-// 		ori svg.Pos
-// 	)
-// 	pos.X = serie.X.Scale(slices.Fst(serie.Points).X)
-// 	pos.Y = serie.Y.Scale(slices.Fst(serie.Points).Y)
-// 	pat.AbsMoveTo(pos)
-// 	if r.Point != nil {
-// 		grp.Append(r.Point(pos))
-// 	}
-// 	ori = pos
-// 	for _, pt := range slices.Rest(serie.Points) {
-// 		pos.X = serie.X.Scale(pt.X)
-// 		pos.Y = serie.Y.Scale(pt.Y)
-
-// 		var (
-// 			ctrl1 = ori
-// 			ctrl2 = pos
-// 			diff  = (pos.X - ori.X) * r.Stretch
-// 		)
-// 		ctrl1.X += diff
-// 		ctrl2.X -= diff
-
-// 		pat.AbsCubicCurve(pos, ctrl1, ctrl2)
-// 		ori = pos
-// 		if r.Point != nil {
-// 			grp.Append(r.Point(pos))
-// 		}
-// 	}
-// 	grp.Append(pat.AsElement())
-// 	return grp.AsElement()
-// }
+// horizontalBarLabel gives the label of the value of a horizontal bar whose
+// top left corner is at pos.
+func horizontalBarLabel(labels Labels, size, value float64, pos svg.Pos, width, height float64) label {
+	lb := label{
+		Text: labels.format(value),
+		Pos:  svg.NewPos(pos.X+width, pos.Y+height/2),
+	}
+	switch labels.Position {
+	case LabelOutside:
+		lb.Pos.X += size * 0.4
+		lb.Anchor = "start"
+	case LabelEnd:
+		lb.Pos.X -= size * 0.4
+		lb.Anchor = "end"
+	default:
+		lb.Pos.X -= width / 2
+		lb.Anchor = "middle"
+	}
+	return lb
+}
 
-type CurveType int
+type HorizontalGroupRenderer[T ~float64, U ~string] struct {
+	Style
+	Width  float64
+	Labels Labels
+}
 
-const (
-	CurveLine CurveType = 1 << iota
-	CurveStep
-	CurveBefore
-	CurveAfter
-	CurveCubic
+func (r HorizontalGroupRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	if r.Width <= 0 {
+		r.Width = 1
+	}
+	var (
+		pal    = r.FillList.Clone()
+		grp    = classGroup("bar", "bar-horizontal")
+		sub    = serie.Y.replace(NewRange(0, serie.Y.Space()))
+		labels []label
+	)
+	for _, pt := range serie.Points {
+		r.FillList = pal.Clone()
+		g := classGroup("group", "bar-group")
+		g.Transform = svg.Translate(0, serie.Y.Scale(pt.Y))
+
+		if r, ok := sub.(scalerReset[U]); ok {
+			var dat []U
+			for _, s := range pt.Sub {
+				dat = append(dat, s.Y)
+			}
+			sub = r.reset(dat)
+		}
+		for _, s := range pt.Sub {
+			var (
+				height = sub.Space() * r.Width
+				width  = serie.X.Scale(s.X) - serie.X.Min()
+				offset = (sub.Space() - height) / 2
+				rec    = r.Rect(width, height)
+			)
+			rec.Pos = svg.NewPos(serie.X.Min(), sub.Scale(s.Y)+offset)
+			rec.Data = serie.crossData(pt, s)
+			g.Append(rec.AsElement())
+			if r.Labels.Visible() {
+				pos := svg.NewPos(rec.Pos.X, rec.Pos.Y+serie.Y.Scale(pt.Y))
+				labels = append(labels, horizontalBarLabel(r.Labels, r.FontSize, float64(s.X), pos, width, height))
+			}
+		}
+		grp.Append(g.AsElement())
+	}
+	if len(labels) > 0 {
+		spreadLabels(labels, r.FontSize, 0)
+		grp.Append(r.drawLabels(labels))
+	}
+	return grp.AsElement()
+}
+
+type HorizontalStackedRenderer[T ~float64, U ~string] struct {
+	Style
+	Width     float64
+	Normalize bool
+	Labels    Labels
+}
+
+func (r HorizontalStackedRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	if r.Width <= 0 {
+		r.Width = 1
+	}
+	var (
+		grp    svg.Group
+		pal    = r.FillList.Clone()
+		min    = serie.X.Min()
+		size   = serie.Y.Space()
+		labels []label
+	)
+	for _, parent := range serie.Points {
+		r.FillList = pal.Clone()
+		var (
+			offset float64
+			bar    = classGroup("bar", "bar-stack")
+		)
+		bar.Transform = svg.Translate(0, serie.Y.Scale(parent.Y))
+		for _, pt := range parent.Sub {
+			var (
+				data  = serie.crossData(parent, pt)
+				value = float64(pt.X)
+			)
+			if r.Normalize {
+				pt.X = pt.X / parent.X
+			}
+			var (
+				wid = serie.X.Scale(pt.X) - min
+				hei = size * r.Width
+				off = (size - hei) / 2
+				rec = r.Rect(wid, hei)
+			)
+			rec.Pos = svg.NewPos(min+offset, off)
+			rec.Data = data
+			bar.Append(rec.AsElement())
+			if r.Labels.Visible() && r.Labels.Position != LabelOutside {
+				pos := svg.NewPos(rec.Pos.X, rec.Pos.Y+serie.Y.Scale(parent.Y))
+				labels = append(labels, horizontalBarLabel(r.Labels, r.FontSize, value, pos, wid, hei))
+			}
+
+			offset += wid
+		}
+		if r.Labels.Visible() && r.Labels.Position == LabelOutside {
+			var (
+				hei = size * r.Width
+				pos = svg.NewPos(min, serie.Y.Scale(parent.Y)+(size-hei)/2)
+			)
+			labels = append(labels, horizontalBarLabel(r.Labels, r.FontSize, float64(parent.X), pos, offset, hei))
+		}
+		grp.Append(bar.AsElement())
+	}
+	if len(labels) > 0 {
+		spreadLabels(labels, r.FontSize, 0)
+		grp.Append(r.drawLabels(labels))
+	}
+	return grp.AsElement()
+}
+
+// PointRenderer draws a marker for each point of the serie. With MaxSize, the
+// area of the markers is proportional to the Size of their point, the largest
+// Size getting a marker of MaxSize. With Colors, the Value of the points gives
+// the color of their marker.
+type PointRenderer[T, U ScalerConstraint] struct {
+	Fill    string
+	Point   PointFunc
+	Size    float64
+	MaxSize float64
+	Colors  ColorScale
+	Error   ErrorStyle
+	Labels  Labels
+}
+
+func (r PointRenderer[T, U]) Swatch() Swatch {
+	if r.Point == nil {
+		r.Point = GetCircle
+	}
+	return Swatch{
+		LineColor: r.Fill,
+		Point:     r.Point,
+	}
+}
+
+func (r PointRenderer[T, U]) ColorScale() ColorScale {
+	return r.Colors
+}
+
+func (r PointRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	if r.Point == nil {
+		r.Point = GetCircle
+	}
+	if r.Size <= 0 {
+		r.Size = DefaultSize
+	}
+	var (
+		grp    = classGroup("scatter")
+		sizer  = r.sizeScaler(serie)
+		points = serie.Points
+		labels []label
+	)
+	if sizer != nil {
+		// largest markers first to keep the smallest ones visible
+		points = make([]Point[T, U], len(serie.Points))
+		copy(points, serie.Points)
+		sort.SliceStable(points, func(i, j int) bool {
+			return points[i].Size > points[j].Size
+		})
+	}
+	if r.Error.Visible {
+		grp.Append(drawWhiskers(serie, r.Error.withDefaults(r.Fill), 0))
+	}
+	for _, pt := range points {
+		var (
+			x    = serie.X.Scale(pt.X)
+			y    = serie.Y.Scale(pt.Y)
+			size = r.Size
+			fill = r.Fill
+		)
+		if sizer != nil {
+			size = sizer.Scale(math.Max(0, pt.Size))
+		}
+		if len(r.Colors.Colors) > 0 {
+			fill = r.Colors.Color(pt.Value)
+		}
+		el := r.Point(svg.NewPos(x, y), size, fill)
+		if data := serie.pointData(pt); len(data) > 0 {
+			g := classGroup("point")
+			g.Data = data
+			g.Append(el)
+			el = g.AsElement()
+		}
+		grp.Append(el)
+		if v, ok := any(pt.Y).(float64); ok && r.Labels.Visible() {
+			labels = append(labels, r.pointLabel(v, svg.NewPos(x, y), size))
+		}
+	}
+	if len(labels) > 0 {
+		style := DefaultStyle()
+		spreadLabels(labels, style.FontSize, style.FontSize*2)
+		grp.Append(style.drawLabels(labels))
+	}
+	return grp.AsElement()
+}
+
+func (r PointRenderer[T, U]) pointLabel(value float64, pos svg.Pos, size float64) label {
+	lb := label{
+		Text:   r.Labels.format(value),
+		Pos:    pos,
+		Anchor: "middle",
+	}
+	switch r.Labels.Position {
+	case LabelOutside:
+		lb.Pos.Y -= size/2 + FontSize*0.8
+	case LabelEnd:
+		lb.Pos.X += size/2 + FontSize*0.4
+		lb.Anchor = "start"
+	default:
+	}
+	return lb
+}
+
+// sizeScaler gives the scaler of the size of the markers. The size grows as
+// the square root of the Size of the points to keep their area proportional.
+func (r PointRenderer[T, U]) sizeScaler(serie Serie[T, U]) Scaler[float64] {
+	if r.MaxSize <= 0 {
+		return nil
+	}
+	var max float64
+	for _, pt := range serie.Points {
+		max = math.Max(max, pt.Size)
+	}
+	if max == 0 {
+		return nil
+	}
+	return SqrtScaler(NumberDomain(0, max), NewRange(0, r.MaxSize))
+}
+
+type CurveType int
+
+const (
+	CurveLine CurveType = 1 << iota
+	CurveStep
+	CurveBefore
+	CurveAfter
+	CurveCubic
+	CurveMonotone
+	CurveNatural
 )
 
 func (c CurveType) Classname() []string {
@@ -549,27 +1397,60 @@ func (c CurveType) Classname() []string {
 		return []string{"line", "line-step", "step-after"}
 	case CurveCubic:
 		return []string{"line", "line-cubic"}
+	case CurveMonotone:
+		return []string{"line", "line-cubic", "line-monotone"}
+	case CurveNatural:
+		return []string{"line", "line-cubic", "line-natural"}
 	}
 }
 
+// AreaRenderer fills the area between the values of a serie and its Baseline.
+// The area goes down to the bottom of the chart when Baseline is NaN.
 type AreaRenderer[T, U ScalerConstraint] struct {
 	LinearRenderer[T, U]
+	Baseline float64
 }
 
 func Area[T, U ScalerConstraint]() AreaRenderer[T, U] {
+	return createAreaRenderer[T, U](CurveLine)
+}
+
+func AreaStep[T, U ScalerConstraint]() AreaRenderer[T, U] {
+	return createAreaRenderer[T, U](CurveStep)
+}
+
+func AreaStepBefore[T, U ScalerConstraint]() AreaRenderer[T, U] {
+	return createAreaRenderer[T, U](CurveBefore)
+}
+
+func AreaStepAfter[T, U ScalerConstraint]() AreaRenderer[T, U] {
+	return createAreaRenderer[T, U](CurveAfter)
+}
+
+func createAreaRenderer[T, U ScalerConstraint](curve CurveType) AreaRenderer[T, U] {
 	return AreaRenderer[T, U]{
-		LinearRenderer: Line[T, U](),
+		LinearRenderer: createLinearRenderer[T, U](curve),
+		Baseline:       math.NaN(),
 	}
 }
 
+func (r AreaRenderer[T, U]) Swatch() Swatch {
+	sw := r.Style.Swatch()
+	if sw.Fill == "" {
+		sw.Fill = r.LineColor
+	}
+	return sw
+}
+
 func (r AreaRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 	var (
-		grp = classGroup(r.Type.Classname()...)
-		pat = r.renderLine(serie, true)
+		base = r.basePosition(serie)
+		grp  = classGroup(r.Type.Classname()...)
+		pat  = r.renderPath(serie, base)
 	)
 	var (
 		lst = slices.Lst(serie.Points)
-		pos = svg.NewPos(serie.X.Scale(lst.X), serie.Y.Max())
+		pos = svg.NewPos(serie.X.Scale(lst.X), base)
 	)
 	pat.AbsLineTo(pos)
 
@@ -582,6 +1463,101 @@ func (r AreaRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
 	if el := r.renderText(serie); el != nil {
 		grp.Append(el)
 	}
+	if el := serie.renderHits(); el != nil {
+		grp.Append(el)
+	}
+	return grp.AsElement()
+}
+
+// basePosition gives the position of the baseline. Only number y values can
+// have a baseline other than the bottom of the chart.
+func (r AreaRenderer[T, U]) basePosition(serie Serie[T, U]) float64 {
+	if math.IsNaN(r.Baseline) {
+		return serie.Y.Max()
+	}
+	if base, ok := any(r.Baseline).(U); ok {
+		return serie.Y.Scale(base)
+	}
+	return serie.Y.Max()
+}
+
+// StackedAreaRenderer fills the area between the values of a serie and the
+// baseline given by the Value of its points. The series are expected to be
+// stacked with StackAreas before being drawn.
+type StackedAreaRenderer[T, U ScalerConstraint] struct {
+	Style
+	Offset StackOffset
+}
+
+func (r StackedAreaRenderer[T, U]) Swatch() Swatch {
+	sw := r.Style.Swatch()
+	if sw.Fill == "" {
+		sw.Fill = r.LineColor
+	}
+	return sw
+}
+
+// Baselines gives the bottom of the layer for each point of the serie.
+func (r StackedAreaRenderer[T, U]) Baselines(serie Serie[T, U]) []U {
+	var list []U
+	for _, pt := range serie.Points {
+		if y, ok := any(pt.Value).(U); ok {
+			list = append(list, y)
+		}
+	}
+	return list
+}
+
+func (r StackedAreaRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	grp := classGroup("area", "area-stack")
+	if len(serie.Points) == 0 {
+		return grp.AsElement()
+	}
+	fill := r.FillList.Curr()
+	if fill == "" {
+		fill = r.LineColor
+	}
+	pat := r.basePath(fill)
+	for i, pt := range serie.Points {
+		pos := svg.NewPos(serie.X.Scale(pt.X), serie.Y.Scale(pt.Y))
+		if i == 0 {
+			pat.AbsMoveTo(pos)
+		} else {
+			pat.AbsLineTo(pos)
+		}
+	}
+	for i := len(serie.Points) - 1; i >= 0; i-- {
+		var (
+			pt   = serie.Points[i]
+			base U
+		)
+		if y, ok := any(pt.Value).(U); ok {
+			base = y
+		}
+		pat.AbsLineTo(svg.NewPos(serie.X.Scale(pt.X), serie.Y.Scale(base)))
+	}
+	pat.ClosePath()
+	grp.Append(pat.AsElement())
+
+	if !serie.interactive() {
+		return grp.AsElement()
+	}
+	hits := classGroup("hits")
+	for _, pt := range serie.Points {
+		var (
+			el  svg.Circle
+			val = pt
+		)
+		if v, ok := any(pt.Y).(float64); ok {
+			val.Y, _ = any(v - pt.Value).(U)
+		}
+		el.Pos = svg.NewPos(serie.X.Scale(pt.X), serie.Y.Scale(pt.Y))
+		el.Radius = DefaultSize
+		el.Fill = svg.NewFill("transparent")
+		el.Data = serie.pointData(val)
+		hits.Append(el.AsElement())
+	}
+	grp.Append(hits.AsElement())
 	return grp.AsElement()
 }
 
@@ -590,6 +1566,8 @@ type LinearRenderer[T, U ScalerConstraint] struct {
 	Text          TextPosition
 	IgnoreMissing bool
 	Type          CurveType
+	Tension       float64
+	Error         ErrorStyle
 }
 
 func Line[T, U ScalerConstraint]() LinearRenderer[T, U] {
@@ -608,6 +1586,20 @@ func StepAfter[T, U ScalerConstraint]() LinearRenderer[T, U] {
 	return createLinearRenderer[T, U](CurveAfter)
 }
 
+func Cubic[T, U ScalerConstraint](tension float64) LinearRenderer[T, U] {
+	r := createLinearRenderer[T, U](CurveCubic)
+	r.Tension = tension
+	return r
+}
+
+func Monotone[T, U ScalerConstraint]() LinearRenderer[T, U] {
+	return createLinearRenderer[T, U](CurveMonotone)
+}
+
+func Natural[T, U ScalerConstraint]() LinearRenderer[T, U] {
+	return createLinearRenderer[T, U](CurveNatural)
+}
+
 func createLinearRenderer[T, U ScalerConstraint](curve CurveType) LinearRenderer[T, U] {
 	return LinearRenderer[T, U]{
 		Type:  curve,
@@ -615,39 +1607,90 @@ func createLinearRenderer[T, U ScalerConstraint](curve CurveType) LinearRenderer
 	}
 }
 
+func (r LinearRenderer[T, U]) Swatch() Swatch {
+	sw := r.Style.Swatch()
+	sw.Fill = ""
+	return sw
+}
+
 func (r LinearRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
-	var (
-		grp = classGroup(r.Type.Classname()...)
-		pat svg.Path
-	)
-	switch r.Type {
-	case CurveLine:
-		pat = r.renderLine(serie, false)
-	case CurveStep:
-		pat = r.renderStep(serie, false)
-	case CurveBefore:
-		pat = r.renderStepBefore(serie, false)
-	case CurveAfter:
-		pat = r.renderStepAfter(serie, false)
-	default:
+	grp := classGroup(r.Type.Classname()...)
+	if len(serie.Points) == 0 {
 		return grp.AsElement()
 	}
+	if r.Error.Visible {
+		if el := drawBand(serie, r.Error.withDefaults(r.LineColor)); el != nil {
+			grp.Append(el)
+		}
+	}
+	pat := r.renderPath(serie, math.NaN())
 	grp.Append(pat.AsElement())
 	if el := r.renderText(serie); el != nil {
 		grp.Append(el)
 	}
+	if el := serie.renderHits(); el != nil {
+		grp.Append(el)
+	}
 	return grp.AsElement()
 }
 
-func (r LinearRenderer[T, U]) renderLine(serie Serie[T, U], zero bool) svg.Path {
+// renderPath gives the path of the serie. When base is a position, the path
+// starts and ends there so that it can be filled.
+func (r LinearRenderer[T, U]) renderPath(serie Serie[T, U], base float64) svg.Path {
+	switch r.Type {
+	case CurveStep:
+		return r.renderStep(serie, base)
+	case CurveBefore:
+		return r.renderStepBefore(serie, base)
+	case CurveAfter:
+		return r.renderStepAfter(serie, base)
+	case CurveCubic:
+		return r.renderCurve(serie, base, cardinalCurve(r.Tension))
+	case CurveMonotone:
+		return r.renderCurve(serie, base, monotoneCurve)
+	case CurveNatural:
+		return r.renderCurve(serie, base, naturalCurve)
+	default:
+		return r.renderLine(serie, base)
+	}
+}
+
+func (r LinearRenderer[T, U]) renderCurve(serie Serie[T, U], base float64, curve curveFunc) svg.Path {
 	var (
-		pat = r.linePath()
-		pos svg.Pos
-		nan bool
+		pat    = r.linePath()
+		points []svg.Pos
+	)
+	for _, pt := range serie.Points {
+		if isNaN(pt.Y) {
+			continue
+		}
+		pos := svg.NewPos(serie.X.Scale(pt.X), serie.Y.Scale(pt.Y))
+		points = append(points, pos)
+	}
+	if len(points) == 0 {
+		return pat
+	}
+	if !math.IsNaN(base) {
+		fst := slices.Fst(points)
+		pat.AbsMoveTo(svg.NewPos(fst.X, base))
+		pat.AbsLineTo(fst)
+	} else {
+		pat.AbsMoveTo(slices.Fst(points))
+	}
+	curve(&pat, points)
+	return pat
+}
+
+func (r LinearRenderer[T, U]) renderLine(serie Serie[T, U], base float64) svg.Path {
+	var (
+		pat  = r.linePath()
+		pos  svg.Pos
+		nan  bool
+		zero = !math.IsNaN(base)
 	)
 	if zero {
 		fst := slices.Fst(serie.Points)
-		pos := svg.NewPos(serie.X.Scale(fst.X), serie.Y.Max())
+		pos := svg.NewPos(serie.X.Scale(fst.X), base)
 		pat.AbsMoveTo(pos)
 	}
 	for i, pt := range serie.Points {
@@ -666,10 +1709,10 @@ func (r LinearRenderer[T, U]) renderLine(serie Serie[T, U], zero bool) svg.Path
 	return pat
 }
 
-func (r LinearRenderer[T, U]) renderStep(serie Serie[T, U], zero bool) svg.Path {
+func (r LinearRenderer[T, U]) renderStep(serie Serie[T, U], base float64) svg.Path {
 	var (
 		pat = r.linePath()
-		pos = svg.NewPos(serie.X.Min(), serie.Y.Max())
+		pos = svg.NewPos(serie.X.Min(), baseOrBottom(serie, base))
 		ori svg.Pos
 		nan bool
 	)
@@ -702,7 +1745,7 @@ func (r LinearRenderer[T, U]) renderStep(serie Serie[T, U], zero bool) svg.Path
 	return pat
 }
 
-func (r LinearRenderer[T, U]) renderStepAfter(serie Serie[T, U], zero bool) svg.Path {
+func (r LinearRenderer[T, U]) renderStepAfter(serie Serie[T, U], base float64) svg.Path {
 	var (
 		pat = r.linePath()
 		pos svg.Pos
@@ -711,7 +1754,7 @@ func (r LinearRenderer[T, U]) renderStepAfter(serie Serie[T, U], zero bool) svg.
 	)
 
 	pos.X = serie.X.Scale(slices.Fst(serie.Points).X)
-	pos.Y = serie.Y.Max()
+	pos.Y = baseOrBottom(serie, base)
 	pat.AbsMoveTo(pos)
 	pos.Y = serie.Y.Scale(slices.Fst(serie.Points).Y)
 	pat.AbsLineTo(pos)
@@ -739,7 +1782,7 @@ func (r LinearRenderer[T, U]) renderStepAfter(serie Serie[T, U], zero bool) svg.
 	return pat
 }
 
-func (r LinearRenderer[T, U]) renderStepBefore(serie Serie[T, U], zero bool) svg.Path {
+func (r LinearRenderer[T, U]) renderStepBefore(serie Serie[T, U], base float64) svg.Path {
 	var (
 		pat = r.linePath()
 		pos svg.Pos
@@ -748,7 +1791,7 @@ func (r LinearRenderer[T, U]) renderStepBefore(serie Serie[T, U], zero bool) svg
 	)
 
 	pos.X = serie.X.Min()
-	pos.Y = serie.Y.Max()
+	pos.Y = baseOrBottom(serie, base)
 	pat.AbsMoveTo(pos)
 	pos.Y = serie.Y.Scale(slices.Fst(serie.Points).Y)
 	pat.AbsLineTo(pos)
@@ -778,6 +1821,13 @@ func (r LinearRenderer[T, U]) renderStepBefore(serie Serie[T, U], zero bool) svg
 	return pat
 }
 
+func baseOrBottom[T, U ScalerConstraint](serie Serie[T, U], base float64) float64 {
+	if math.IsNaN(base) {
+		return serie.Y.Max()
+	}
+	return base
+}
+
 func (r LinearRenderer[T, U]) renderText(serie Serie[T, U]) svg.Element {
 	switch txt := r.Style.Text(serie.Title); r.Text {
 	case TextBefore:
@@ -817,3 +1867,576 @@ func isNaN[T any](v T) bool {
 	}
 	return math.IsNaN(f)
 }
+
+const (
+	candleUp   = "#2ca02c"
+	candleDown = "#d62728"
+)
+
+type CandleRenderer[T, U ScalerConstraint] struct {
+	Style
+	Width float64
+	Up    string
+	Down  string
+}
+
+func (r CandleRenderer[T, U]) Swatch() Swatch {
+	r.setDefaults()
+	sw := r.Style.Swatch()
+	sw.Fill = r.Up
+	sw.LineColor = r.Down
+	return sw
+}
+
+func (r CandleRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	r.setDefaults()
+	var (
+		grp   = classGroup("candle")
+		width = pointSpace(serie) * r.Width
+	)
+	for _, pt := range serie.Points {
+		open, high, low, close, ok := pt.ohlc()
+		if !ok {
+			continue
+		}
+		var (
+			x      = serie.X.Scale(pt.X)
+			yo     = serie.Y.Scale(open)
+			yc     = serie.Y.Scale(close)
+			color  = r.Up
+			g      = classGroup("candle-up")
+			top    = math.Min(yo, yc)
+			height = math.Max(math.Abs(yo-yc), 1)
+		)
+		if yc > yo {
+			color = r.Down
+			g = classGroup("candle-down")
+		}
+		g.Data = candleData(serie, pt, open, high, low, close)
+
+		wick := svg.NewLine(svg.NewPos(x, serie.Y.Scale(high)), svg.NewPos(x, serie.Y.Scale(low)))
+		wick.Stroke = svg.NewStroke(color, r.LineWidth)
+		g.Append(wick.AsElement())
+
+		var body svg.Rect
+		body.Pos = svg.NewPos(x-width/2, top)
+		body.Dim = svg.NewDim(width, height)
+		body.Fill = svg.NewFill(color)
+		body.Fill.Opacity = r.FillOpacity
+		body.Stroke = svg.NewStroke(color, r.LineWidth)
+		g.Append(body.AsElement())
+
+		grp.Append(g.AsElement())
+	}
+	return grp.AsElement()
+}
+
+func (r *CandleRenderer[T, U]) setDefaults() {
+	if r.Width <= 0 {
+		r.Width = 0.7
+	}
+	if r.Up == "" {
+		r.Up = candleUp
+	}
+	if r.Down == "" {
+		r.Down = candleDown
+	}
+}
+
+type OHLCRenderer[T, U ScalerConstraint] struct {
+	Style
+	Width float64
+	Up    string
+	Down  string
+}
+
+func (r OHLCRenderer[T, U]) Swatch() Swatch {
+	r.setDefaults()
+	sw := r.Style.Swatch()
+	sw.Fill = ""
+	sw.LineColor = r.Up
+	return sw
+}
+
+func (r OHLCRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	r.setDefaults()
+	var (
+		grp  = classGroup("ohlc")
+		tick = pointSpace(serie) * r.Width / 2
+	)
+	for _, pt := range serie.Points {
+		open, high, low, close, ok := pt.ohlc()
+		if !ok {
+			continue
+		}
+		var (
+			x     = serie.X.Scale(pt.X)
+			yo    = serie.Y.Scale(open)
+			yc    = serie.Y.Scale(close)
+			color = r.Up
+			g     = classGroup("ohlc-up")
+			pat   svg.Path
+		)
+		if yc > yo {
+			color = r.Down
+			g = classGroup("ohlc-down")
+		}
+		g.Data = candleData(serie, pt, open, high, low, close)
+
+		pat.Stroke = svg.NewStroke(color, r.LineWidth)
+		pat.Fill = svg.NewFill(ColorNone)
+		pat.AbsMoveTo(svg.NewPos(x, serie.Y.Scale(high)))
+		pat.AbsLineTo(svg.NewPos(x, serie.Y.Scale(low)))
+		pat.AbsMoveTo(svg.NewPos(x-tick, yo))
+		pat.AbsLineTo(svg.NewPos(x, yo))
+		pat.AbsMoveTo(svg.NewPos(x, yc))
+		pat.AbsLineTo(svg.NewPos(x+tick, yc))
+		g.Append(pat.AsElement())
+
+		grp.Append(g.AsElement())
+	}
+	return grp.AsElement()
+}
+
+func (r *OHLCRenderer[T, U]) setDefaults() {
+	if r.Width <= 0 {
+		r.Width = 0.7
+	}
+	if r.Up == "" {
+		r.Up = candleUp
+	}
+	if r.Down == "" {
+		r.Down = candleDown
+	}
+}
+
+func candleData[T, U ScalerConstraint](serie Serie[T, U], pt Point[T, U], open, high, low, close U) []svg.Datum {
+	data := serie.pointData(pt)
+	if len(data) == 0 {
+		return data
+	}
+	label := fmt.Sprintf("O: %s H: %s L: %s C: %s", serie.formatY(open), serie.formatY(high), serie.formatY(low), serie.formatY(close))
+	return append(data, svg.Datum{Name: "label", Value: dataValue(label)})
+}
+
+// pointSpace gives the smallest distance between two consecutive points of
+// the serie once scaled.
+func pointSpace[T, U ScalerConstraint](serie Serie[T, U]) float64 {
+	space := math.Inf(1)
+	for i := 1; i < len(serie.Points); i++ {
+		d := math.Abs(serie.X.Scale(serie.Points[i].X) - serie.X.Scale(serie.Points[i-1].X))
+		if d > 0 {
+			space = math.Min(space, d)
+		}
+	}
+	if math.IsInf(space, 0) {
+		return DefaultSize * 2
+	}
+	return space
+}
+
+type HistogramRenderer[T ~float64, U ~float64] struct {
+	Style
+	Binning
+}
+
+func (r HistogramRenderer[T, U]) Swatch() Swatch {
+	sw := r.Style.Swatch()
+	if sw.Fill == "" {
+		sw.Fill = r.LineColor
+	}
+	return sw
+}
+
+// Bins distributes the Y values of the serie according to the binning of the
+// renderer.
+func (r HistogramRenderer[T, U]) Bins(serie Serie[T, U]) []Bin {
+	values := make([]float64, 0, len(serie.Points))
+	for _, pt := range serie.Points {
+		values = append(values, float64(pt.Y))
+	}
+	return r.Binning.Bins(values)
+}
+
+func (r HistogramRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	if len(r.FillList) > 1 {
+		r.FillList = r.FillList[:1]
+	}
+	grp := classGroup("bar", "histogram")
+	for _, b := range r.Bins(serie) {
+		var (
+			x0  = serie.X.Scale(T(b.Min))
+			x1  = serie.X.Scale(T(b.Max))
+			y   = serie.Y.Scale(U(b.Value))
+			rec = r.Rect(math.Abs(x1-x0), serie.Y.Max()-y)
+		)
+		if len(r.FillList) == 0 {
+			rec.Fill = svg.NewFill(r.LineColor)
+			rec.Fill.Opacity = r.FillOpacity
+			rec.Stroke = svg.NewStroke("white", 1)
+		}
+		rec.Pos = svg.NewPos(math.Min(x0, x1), y)
+		if serie.interactive() {
+			rec.Data = []svg.Datum{
+				{Name: "x", Value: dataValue(serie.formatX(T(b.Min)) + " - " + serie.formatX(T(b.Max)))},
+				{Name: "y", Value: dataValue(serie.formatY(U(b.Value)))},
+			}
+		}
+		grp.Append(rec.AsElement())
+	}
+	return grp.AsElement()
+}
+
+type BoxRenderer[T ~string, U ~float64] struct {
+	Style
+	Width  float64
+	Violin bool
+}
+
+// Summaries computes the summary of the values of each category of the serie.
+// Values come from the sub points of a point or from the points sharing the
+// same category.
+func (r BoxRenderer[T, U]) Summaries(serie Serie[T, U]) []Summary {
+	_, list := r.summarize(serie)
+	return list
+}
+
+func (r BoxRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	if r.Width <= 0 {
+		r.Width = 0.5
+	}
+	var (
+		grp        = classGroup("box")
+		keys, sums = r.summarize(serie)
+		width      = serie.X.Space() * r.Width
+	)
+	if r.Violin {
+		grp = classGroup("box", "violin")
+	}
+	for i, sum := range sums {
+		if len(sum.Values) == 0 {
+			continue
+		}
+		var (
+			center = serie.X.Scale(keys[i]) + serie.X.Space()/2
+			fill   = ColorNone
+			color  = r.LineColor
+			g      = classGroup("box-item")
+			box    = width
+		)
+		if len(r.FillList) > 0 {
+			fill = r.FillList.Next()
+		}
+		if color == "" || color == ColorNone {
+			color = ColorBlack
+		}
+		if r.Violin {
+			g.Append(r.drawViolin(serie, center, width, sum, fill, color))
+			box = width / 6
+			fill = color
+		}
+		g.Append(r.drawBox(serie, center, box, sum, fill, color))
+		for _, v := range sum.Outliers {
+			var ci svg.Circle
+			ci.Class = append(ci.Class, "outlier")
+			ci.Pos = svg.NewPos(center, serie.Y.Scale(U(v)))
+			ci.Radius = DefaultSize / 2
+			ci.Fill = svg.NewFill(ColorNone)
+			ci.Stroke = svg.NewStroke(color, r.LineWidth)
+			if serie.interactive() {
+				ci.Data = []svg.Datum{
+					{Name: "x", Value: dataValue(serie.formatX(keys[i]))},
+					{Name: "y", Value: dataValue(serie.formatY(U(v)))},
+				}
+			}
+			g.Append(ci.AsElement())
+		}
+		if serie.interactive() {
+			var (
+				fy    = serie.formatY
+				label = fmt.Sprintf("min: %s Q1: %s median: %s Q3: %s max: %s", fy(U(sum.Min)), fy(U(sum.Q1)), fy(U(sum.Median)), fy(U(sum.Q3)), fy(U(sum.Max)))
+			)
+			g.Data = []svg.Datum{
+				{Name: "x", Value: dataValue(serie.formatX(keys[i]))},
+				{Name: "y", Value: dataValue(fy(U(sum.Median)))},
+				{Name: "label", Value: dataValue(label)},
+			}
+		}
+		grp.Append(g.AsElement())
+	}
+	return grp.AsElement()
+}
+
+func (r BoxRenderer[T, U]) drawBox(serie Serie[T, U], center, width float64, sum Summary, fill, color string) svg.Element {
+	var (
+		grp    = classGroup("box-summary")
+		stroke = svg.NewStroke(color, r.LineWidth)
+		q1     = serie.Y.Scale(U(sum.Q1))
+		q3     = serie.Y.Scale(U(sum.Q3))
+		low    = serie.Y.Scale(U(sum.Min))
+		high   = serie.Y.Scale(U(sum.Max))
+		med    = serie.Y.Scale(U(sum.Median))
+		half   = width / 2
+		pat    svg.Path
+	)
+	pat.Stroke = stroke
+	pat.Fill = svg.NewFill(ColorNone)
+	pat.AbsMoveTo(svg.NewPos(center, q3))
+	pat.AbsLineTo(svg.NewPos(center, high))
+	pat.AbsMoveTo(svg.NewPos(center-half/2, high))
+	pat.AbsLineTo(svg.NewPos(center+half/2, high))
+	pat.AbsMoveTo(svg.NewPos(center, q1))
+	pat.AbsLineTo(svg.NewPos(center, low))
+	pat.AbsMoveTo(svg.NewPos(center-half/2, low))
+	pat.AbsLineTo(svg.NewPos(center+half/2, low))
+	grp.Append(pat.AsElement())
+
+	var rec svg.Rect
+	rec.Pos = svg.NewPos(center-half, math.Min(q1, q3))
+	rec.Dim = svg.NewDim(width, math.Abs(q1-q3))
+	rec.Fill = svg.NewFill(fill)
+	rec.Fill.Opacity = r.FillOpacity
+	rec.Stroke = stroke
+	grp.Append(rec.AsElement())
+
+	median := svg.NewLine(svg.NewPos(center-half, med), svg.NewPos(center+half, med))
+	median.Stroke = svg.NewStroke(color, r.LineWidth*2)
+	if r.Violin {
+		median.Stroke = svg.NewStroke("white", r.LineWidth*2)
+	}
+	grp.Append(median.AsElement())
+	return grp.AsElement()
+}
+
+// drawViolin draws the estimated density of the values. Each violin uses the
+// full width available at its widest point.
+func (r BoxRenderer[T, U]) drawViolin(serie Serie[T, U], center, width float64, sum Summary, fill, color string) svg.Element {
+	var (
+		pos, dens = sum.Density(50)
+		ratio     float64
+		pat       svg.Path
+	)
+	for _, d := range dens {
+		ratio = math.Max(ratio, d)
+	}
+	if ratio > 0 {
+		ratio = width / 2 / ratio
+	}
+	pat.Class = append(pat.Class, "violin-shape")
+	pat.Fill = svg.NewFill(fill)
+	pat.Fill.Opacity = r.FillOpacity
+	pat.Stroke = svg.NewStroke(color, r.LineWidth)
+	for i := range pos {
+		p := svg.NewPos(center+dens[i]*ratio, serie.Y.Scale(U(pos[i])))
+		if i == 0 {
+			pat.AbsMoveTo(p)
+		} else {
+			pat.AbsLineTo(p)
+		}
+	}
+	for i := len(pos) - 1; i >= 0; i-- {
+		pat.AbsLineTo(svg.NewPos(center-dens[i]*ratio, serie.Y.Scale(U(pos[i]))))
+	}
+	pat.ClosePath()
+	return pat.AsElement()
+}
+
+func (r BoxRenderer[T, U]) summarize(serie Serie[T, U]) ([]T, []Summary) {
+	var (
+		keys   []T
+		values = make(map[T][]float64)
+	)
+	for _, pt := range serie.Points {
+		if _, ok := values[pt.X]; !ok {
+			keys = append(keys, pt.X)
+		}
+		if pt.isLeaf() {
+			values[pt.X] = append(values[pt.X], float64(pt.Y))
+			continue
+		}
+		for _, s := range pt.Sub {
+			values[pt.X] = append(values[pt.X], float64(s.Y))
+		}
+	}
+	list := make([]Summary, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, Summarize(values[k]))
+	}
+	return keys, list
+}
+
+type HeatmapRenderer[T, U ScalerConstraint] struct {
+	Style
+	Scale ColorScale
+}
+
+func (r HeatmapRenderer[T, U]) ColorScale() ColorScale {
+	return r.Scale
+}
+
+func (r HeatmapRenderer[T, U]) Render(serie Serie[T, U]) svg.Element {
+	var (
+		grp = classGroup("heatmap")
+		xs  = make([]T, 0, len(serie.Points))
+		ys  = make([]U, 0, len(serie.Points))
+	)
+	for _, pt := range serie.Points {
+		xs = append(xs, pt.X)
+		ys = append(ys, pt.Y)
+	}
+	var (
+		width  = cellSpace(serie.X, xs)
+		height = cellSpace(serie.Y, ys)
+	)
+	for _, pt := range serie.Points {
+		if math.IsNaN(pt.Value) {
+			continue
+		}
+		var (
+			rec  svg.Rect
+			x    = cellPosition(serie.X, pt.X, width)
+			y    = cellPosition(serie.Y, pt.Y, height)
+			fill = r.Scale.Color(pt.Value)
+		)
+		rec.Pos = svg.NewPos(x, y)
+		rec.Dim = svg.NewDim(width, height)
+		rec.Fill = svg.NewFill(fill)
+		rec.Fill.Opacity = r.FillOpacity
+		if r.LineColor != "" && r.LineWidth > 0 {
+			rec.Stroke = svg.NewStroke(r.LineColor, r.LineWidth)
+			rec.Stroke.Opacity = r.LineOpacity
+		}
+		if data := serie.pointData(pt); len(data) > 0 {
+			value := strconv.FormatFloat(pt.Value, 'f', -1, 64)
+			rec.Data = append(data, svg.Datum{Name: "label", Value: dataValue(value)})
+		}
+		grp.Append(rec.AsElement())
+	}
+	return grp.AsElement()
+}
+
+// cellSpace gives the size of a cell along an axis: the band of a category
+// scaler or the smallest distance between two values otherwise.
+func cellSpace[T ScalerConstraint](scale Scaler[T], values []T) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	if _, ok := any(values[0]).(string); ok {
+		return scale.Space()
+	}
+	var list []float64
+	for _, v := range values {
+		list = append(list, scale.Scale(v))
+	}
+	sort.Float64s(list)
+	space := math.Inf(1)
+	for i := 1; i < len(list); i++ {
+		if d := list[i] - list[i-1]; d > 0 {
+			space = math.Min(space, d)
+		}
+	}
+	if math.IsInf(space, 0) {
+		return DefaultSize * 2
+	}
+	return space
+}
+
+// cellPosition gives the start of the cell of the value. Category scalers
+// already give the start of the band of the value while other scalers give
+// the center of the cell.
+func cellPosition[T ScalerConstraint](scale Scaler[T], value T, space float64) float64 {
+	pos := scale.Scale(value)
+	if _, ok := any(value).(string); ok {
+		return pos
+	}
+	return pos - space/2
+}
+
+// drawBand gives the area between the lower and the upper bounds of the
+// points of the serie.
+func drawBand[T, U ScalerConstraint](serie Serie[T, U], style ErrorStyle) svg.Element {
+	var upper, lower []svg.Pos
+	for _, pt := range serie.Points {
+		lo, hi, ok := pt.bounds()
+		if !ok || isNaN(lo) || isNaN(hi) {
+			continue
+		}
+		x := serie.X.Scale(pt.X)
+		upper = append(upper, svg.NewPos(x, serie.Y.Scale(hi)))
+		lower = append(lower, svg.NewPos(x, serie.Y.Scale(lo)))
+	}
+	if len(upper) == 0 {
+		return nil
+	}
+	var pg svg.Polygon
+	pg.Class = append(pg.Class, "error-band")
+	pg.Points = upper
+	for i := len(lower) - 1; i >= 0; i-- {
+		pg.Points = append(pg.Points, lower[i])
+	}
+	pg.Fill = svg.NewFill(style.Color)
+	pg.Fill.Opacity = style.Opacity
+	return pg.AsElement()
+}
+
+// drawWhiskers gives a vertical line between the lower and the upper bounds
+// of each point of the serie, shifted by offset from the position of the
+// point.
+func drawWhiskers[T, U ScalerConstraint](serie Serie[T, U], style ErrorStyle, offset float64) svg.Element {
+	var (
+		pat  svg.Path
+		half = style.Cap / 2
+	)
+	pat.Class = append(pat.Class, "error-bar")
+	pat.Fill = svg.NewFill(ColorNone)
+	pat.Stroke = svg.NewStroke(style.Color, style.Width)
+	for _, pt := range serie.Points {
+		lo, hi, ok := pt.bounds()
+		if !ok || isNaN(lo) || isNaN(hi) {
+			continue
+		}
+		var (
+			x  = serie.X.Scale(pt.X) + offset
+			y1 = serie.Y.Scale(lo)
+			y2 = serie.Y.Scale(hi)
+		)
+		pat.AbsMoveTo(svg.NewPos(x, y1))
+		pat.AbsLineTo(svg.NewPos(x, y2))
+		pat.AbsMoveTo(svg.NewPos(x-half, y1))
+		pat.AbsLineTo(svg.NewPos(x+half, y1))
+		pat.AbsMoveTo(svg.NewPos(x-half, y2))
+		pat.AbsLineTo(svg.NewPos(x+half, y2))
+	}
+	return pat.AsElement()
+}
+
+// drawHorizontalWhiskers gives a horizontal line between the lower and the
+// upper bounds of each point of a serie having its values on the x axis,
+// shifted by offset from the position of the point.
+func drawHorizontalWhiskers[T, U ScalerConstraint](serie Serie[T, U], style ErrorStyle, offset float64) svg.Element {
+	var (
+		pat  svg.Path
+		half = style.Cap / 2
+	)
+	pat.Class = append(pat.Class, "error-bar")
+	pat.Fill = svg.NewFill(ColorNone)
+	pat.Stroke = svg.NewStroke(style.Color, style.Width)
+	for _, pt := range serie.Points {
+		lo, hi, ok := pt.xbounds()
+		if !ok || isNaN(lo) || isNaN(hi) {
+			continue
+		}
+		var (
+			y  = serie.Y.Scale(pt.Y) + offset
+			x1 = serie.X.Scale(lo)
+			x2 = serie.X.Scale(hi)
+		)
+		pat.AbsMoveTo(svg.NewPos(x1, y))
+		pat.AbsLineTo(svg.NewPos(x2, y))
+		pat.AbsMoveTo(svg.NewPos(x1, y-half))
+		pat.AbsLineTo(svg.NewPos(x1, y+half))
+		pat.AbsMoveTo(svg.NewPos(x2, y-half))
+		pat.AbsLineTo(svg.NewPos(x2, y+half))
+	}
+	return pat.AsElement()
+}
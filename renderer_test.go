@@ -0,0 +1,95 @@
+package charts
+
+import (
+	"math"
+	"testing"
+
+	"github.com/midbel/svg"
+)
+
+func TestTreemapLayout(t *testing.T) {
+	var (
+		values = []float64{6, 6, 4, 3, 2, 2, 1, 0}
+		area   = treeRect{W: 600, H: 400}
+		total  = sumValues(values)
+	)
+	layouts := map[string]func([]float64, treeRect) []treeRect{
+		"squarify": squarifyLayout,
+		"binary":   binaryLayout,
+		"slice-dice": func(values []float64, area treeRect) []treeRect {
+			return sliceDiceLayout(values, area, true)
+		},
+	}
+	for name, layout := range layouts {
+		rects := layout(values, area)
+		if len(rects) != len(values) {
+			t.Errorf("%s: expected %d rectangles, got %d", name, len(values), len(rects))
+			continue
+		}
+		for i, r := range rects {
+			want := values[i] / total * area.W * area.H
+			if got := r.W * r.H; math.Abs(got-want) > 1e-6 {
+				t.Errorf("%s: area mismatched for %f: want %f, got %f", name, values[i], want, got)
+			}
+			if r.X < area.X-1e-6 || r.Y < area.Y-1e-6 || r.X+r.W > area.W+1e-6 || r.Y+r.H > area.H+1e-6 {
+				t.Errorf("%s: %+v outside of area", name, r)
+			}
+		}
+	}
+}
+
+func TestPolarLayers(t *testing.T) {
+	serie := Serie[string, float64]{
+		Points: []Point[string, float64]{
+			{X: "a", Sub: []Point[string, float64]{CategoryPoint("x", 1), CategoryPoint("y", 2)}},
+			{X: "b", Sub: []Point[string, float64]{CategoryPoint("x", 3)}},
+		},
+	}
+	tests := []struct {
+		Stacked bool
+		Want    [][]float64
+	}{
+		{
+			Stacked: false,
+			Want:    [][]float64{{1, 3}, {2, 0}},
+		},
+		{
+			Stacked: true,
+			Want:    [][]float64{{1, 3}, {3, 3}},
+		},
+	}
+	for _, c := range tests {
+		r := PolarRenderer[string, float64]{Stacked: c.Stacked}
+		got := r.polarLayers(serie)
+		if len(got) != len(c.Want) {
+			t.Errorf("layers mismatched: want %d, got %d", len(c.Want), len(got))
+			continue
+		}
+		for j := range got {
+			for i := range got[j] {
+				if got[j][i] != c.Want[j][i] {
+					t.Errorf("stacked(%t): value mismatched at %d/%d: want %f, got %f", c.Stacked, j, i, c.Want[j][i], got[j][i])
+				}
+			}
+		}
+	}
+}
+
+func TestSpreadLabels(t *testing.T) {
+	list := []label{
+		{Text: "10", Pos: svg.NewPos(0, 10)},
+		{Text: "20", Pos: svg.NewPos(0, 12)},
+		{Text: "30", Pos: svg.NewPos(100, 12)},
+		{Text: "40", Pos: svg.NewPos(0, 14)},
+	}
+	spreadLabels(list, 10, 15)
+	if list[0].Pos.Y != 10 || list[2].Pos.Y != 12 {
+		t.Errorf("labels without overlap moved: %+v", list)
+	}
+	if list[1].Hidden || list[1].Pos.Y != 20 {
+		t.Errorf("label not moved below the first one: %+v", list[1])
+	}
+	if !list[3].Hidden {
+		t.Errorf("label moved beyond the limit not hidden: %+v", list[3])
+	}
+}
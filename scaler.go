@@ -44,6 +44,10 @@ func (n numberDomain) Merge(other Domain[float64]) (Domain[float64], error) {
 	return x, nil
 }
 
+func (n numberDomain) bounds() (float64, float64) {
+	return n.fst, n.lst
+}
+
 func (n numberDomain) Diff(v float64) float64 {
 	return v - n.fst
 }
@@ -64,6 +68,14 @@ func (n numberDomain) Values(c int) []float64 {
 	return all
 }
 
+func domainBounds(dom Domain[float64]) (float64, float64) {
+	if b, ok := dom.(interface{ bounds() (float64, float64) }); ok {
+		return b.bounds()
+	}
+	vs := dom.Values(1)
+	return vs[0], vs[len(vs)-1]
+}
+
 type timeDomain struct {
 	fst time.Time
 	lst time.Time
@@ -91,6 +103,10 @@ func (t timeDomain) Merge(other Domain[time.Time]) (Domain[time.Time], error) {
 	return n, nil
 }
 
+func (t timeDomain) bounds() (time.Time, time.Time) {
+	return t.fst, t.lst
+}
+
 func (t timeDomain) Diff(v time.Time) float64 {
 	diff := v.Sub(t.fst)
 	return float64(diff)
@@ -113,6 +129,14 @@ func (t timeDomain) Values(c int) []time.Time {
 	return all
 }
 
+func timeBounds(dom Domain[time.Time]) (time.Time, time.Time) {
+	if b, ok := dom.(interface{ bounds() (time.Time, time.Time) }); ok {
+		return b.bounds()
+	}
+	vs := dom.Values(1)
+	return vs[0], vs[len(vs)-1]
+}
+
 type Range struct {
 	F float64
 	T float64
@@ -179,6 +203,218 @@ func (n numberScaler) normalize() Scaler[float64] {
 	return x
 }
 
+type logScaler struct {
+	Range
+	fst  float64
+	lst  float64
+	base float64
+}
+
+func LogScaler(dom Domain[float64], base float64, rg Range) Scaler[float64] {
+	if base <= 1 {
+		base = 10
+	}
+	fst, lst := domainBounds(dom)
+	return logScaler{
+		Range: rg,
+		fst:   fst,
+		lst:   lst,
+		base:  base,
+	}
+}
+
+func (s logScaler) Scale(v float64) float64 {
+	if v <= 0 {
+		v = math.Min(s.fst, s.lst)
+	}
+	return (s.log(v) - s.log(s.fst)) * s.Space()
+}
+
+func (s logScaler) Space() float64 {
+	return s.Len() / (s.log(s.lst) - s.log(s.fst))
+}
+
+func (s logScaler) Values(c int) []float64 {
+	if c <= 0 {
+		c = 1
+	}
+	var (
+		lo, hi  = orderBounds(s.fst, s.lst)
+		beg     = math.Floor(s.log(lo))
+		end     = math.Ceil(s.log(hi))
+		count   = float64(c) * 1.5
+		decades = end - beg
+		mult    []float64
+		list    []float64
+	)
+	switch {
+	case s.base == math.Trunc(s.base) && decades*(s.base-1) <= count:
+		for k := 1.0; k < s.base; k++ {
+			mult = append(mult, k)
+		}
+	case s.base == 10 && decades*3 <= count:
+		mult = append(mult, 1, 2, 5)
+	}
+	if len(mult) > 0 {
+		for i := beg; i <= end; i++ {
+			p := math.Pow(s.base, i)
+			for _, k := range mult {
+				if v := k * p; v >= lo && v <= hi {
+					list = append(list, v)
+				}
+			}
+		}
+	} else {
+		step := math.Max(1, math.Ceil(decades/float64(c)))
+		for i := beg; i <= end; i += step {
+			if v := math.Pow(s.base, i); v >= lo && v <= hi {
+				list = append(list, v)
+			}
+		}
+	}
+	return orderValues(list, s.fst > s.lst)
+}
+
+func (s logScaler) replace(rg Range) Scaler[float64] {
+	x := s
+	x.Range = rg
+	return x
+}
+
+func (s logScaler) log(v float64) float64 {
+	return math.Log(v) / math.Log(s.base)
+}
+
+type powScaler struct {
+	Range
+	Domain[float64]
+	fst      float64
+	lst      float64
+	exponent float64
+}
+
+func PowScaler(dom Domain[float64], exponent float64, rg Range) Scaler[float64] {
+	if exponent == 0 {
+		exponent = 1
+	}
+	fst, lst := domainBounds(dom)
+	return powScaler{
+		Range:    rg,
+		Domain:   dom,
+		fst:      fst,
+		lst:      lst,
+		exponent: exponent,
+	}
+}
+
+func SqrtScaler(dom Domain[float64], rg Range) Scaler[float64] {
+	return PowScaler(dom, 0.5, rg)
+}
+
+func (s powScaler) Scale(v float64) float64 {
+	return (s.pow(v) - s.pow(s.fst)) * s.Space()
+}
+
+func (s powScaler) Space() float64 {
+	return s.Len() / (s.pow(s.lst) - s.pow(s.fst))
+}
+
+func (s powScaler) replace(rg Range) Scaler[float64] {
+	x := s
+	x.Range = rg
+	return x
+}
+
+func (s powScaler) pow(v float64) float64 {
+	return sign(v) * math.Pow(math.Abs(v), s.exponent)
+}
+
+type symlogScaler struct {
+	Range
+	fst      float64
+	lst      float64
+	constant float64
+}
+
+func SymlogScaler(dom Domain[float64], constant float64, rg Range) Scaler[float64] {
+	if constant <= 0 {
+		constant = 1
+	}
+	fst, lst := domainBounds(dom)
+	return symlogScaler{
+		Range:    rg,
+		fst:      fst,
+		lst:      lst,
+		constant: constant,
+	}
+}
+
+func (s symlogScaler) Scale(v float64) float64 {
+	return (s.symlog(v) - s.symlog(s.fst)) * s.Space()
+}
+
+func (s symlogScaler) Space() float64 {
+	return s.Len() / (s.symlog(s.lst) - s.symlog(s.fst))
+}
+
+func (s symlogScaler) Values(c int) []float64 {
+	var (
+		lo, hi = orderBounds(s.fst, s.lst)
+		list   []float64
+		limit  = math.Max(math.Abs(lo), math.Abs(hi)) / s.constant
+		end    = math.Ceil(math.Log10(math.Max(limit, 1)))
+		step   = 1.0
+	)
+	if c > 0 && end > float64(c)/2 {
+		step = math.Ceil(end * 2 / float64(c))
+	}
+	var exps []float64
+	for i := 0.0; i <= end; i += step {
+		exps = append(exps, i)
+	}
+	for i := len(exps) - 1; i >= 0; i-- {
+		if v := -s.constant * math.Pow(10, exps[i]); v >= lo {
+			list = append(list, v)
+		}
+	}
+	if lo <= 0 && hi >= 0 {
+		list = append(list, 0)
+	}
+	for _, i := range exps {
+		if v := s.constant * math.Pow(10, i); v <= hi {
+			list = append(list, v)
+		}
+	}
+	return orderValues(list, s.fst > s.lst)
+}
+
+func (s symlogScaler) replace(rg Range) Scaler[float64] {
+	x := s
+	x.Range = rg
+	return x
+}
+
+func (s symlogScaler) symlog(v float64) float64 {
+	return sign(v) * math.Log1p(math.Abs(v)/s.constant)
+}
+
+func orderBounds(fst, lst float64) (float64, float64) {
+	if fst > lst {
+		return lst, fst
+	}
+	return fst, lst
+}
+
+func orderValues(list []float64, reverse bool) []float64 {
+	if !reverse {
+		return list
+	}
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return list
+}
+
 type timeScaler struct {
 	Range
 	Domain[time.Time]
@@ -0,0 +1,102 @@
+package charts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNiceNumberTicks(t *testing.T) {
+	data := []struct {
+		Fst   float64
+		Lst   float64
+		Count int
+		Want  []float64
+	}{
+		{
+			Fst:   0,
+			Lst:   142,
+			Count: 3,
+			Want:  []float64{0, 50, 100},
+		},
+		{
+			Fst:   0.12,
+			Lst:   0.91,
+			Count: 4,
+			Want:  []float64{0.2, 0.4, 0.6, 0.8},
+		},
+		{
+			Fst:   100,
+			Lst:   0,
+			Count: 5,
+			Want:  []float64{100, 80, 60, 40, 20, 0},
+		},
+	}
+	for _, d := range data {
+		got := niceNumberTicks(d.Fst, d.Lst, d.Count)
+		if !equalValues(got, d.Want) {
+			t.Errorf("ticks mismatched for %f-%f: want %v, got %v", d.Fst, d.Lst, d.Want, got)
+		}
+	}
+}
+
+func TestNiceNumberDomain(t *testing.T) {
+	dom := niceNumberDomain(3.2, 47.333, 5)
+	if dom.fst != 0 || dom.lst != 50 {
+		t.Errorf("domain not widened: want 0-50, got %f-%f", dom.fst, dom.lst)
+	}
+	dom = niceNumberDomain(47.333, 3.2, 5)
+	if dom.fst != 50 || dom.lst != 0 {
+		t.Errorf("reversed domain not widened: want 50-0, got %f-%f", dom.fst, dom.lst)
+	}
+}
+
+func TestNiceTimeTicks(t *testing.T) {
+	var (
+		fst  = time.Date(2020, 1, 14, 0, 0, 0, 0, time.UTC)
+		lst  = time.Date(2020, 12, 2, 0, 0, 0, 0, time.UTC)
+		list = niceTimeTicks(fst, lst, 4)
+	)
+	if len(list) == 0 {
+		t.Fatalf("no ticks generated")
+	}
+	for _, w := range list {
+		if w.Day() != 1 || (w.Month()-1)%3 != 0 {
+			t.Errorf("tick not aligned on quarter: %s", w)
+		}
+	}
+	list = niceTimeTicks(fst, fst.Add(time.Hour), 4)
+	for _, w := range list {
+		if w.Minute()%15 != 0 || w.Second() != 0 {
+			t.Errorf("tick not aligned on 15 minutes: %s", w)
+		}
+	}
+}
+
+func TestLogScalerValues(t *testing.T) {
+	var (
+		scale = LogScaler(NumberDomain(1, 1e6), 10, NewRange(0, 600))
+		want  = []float64{1, 10, 100, 1000, 10000, 100000, 1e6}
+		got   = scale.Values(6)
+	)
+	if !equalValues(got, want) {
+		t.Errorf("ticks mismatched: want %v, got %v", want, got)
+	}
+	if pos := scale.Scale(1000); !equalValues([]float64{pos}, []float64{300}) {
+		t.Errorf("position mismatched: want 300, got %f", pos)
+	}
+	if pos := scale.Scale(0); pos != 0 {
+		t.Errorf("values below zero should be at the bottom of the scale, got %f", pos)
+	}
+}
+
+func equalValues(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if d := got[i] - want[i]; d > 1e-9 || d < -1e-9 {
+			return false
+		}
+	}
+	return true
+}
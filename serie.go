@@ -2,6 +2,7 @@ package charts
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/midbel/slices"
@@ -12,6 +13,7 @@ import (
 type Data interface {
 	Id() string
 	Render() svg.Element
+	Swatch() Swatch
 
 	fmt.Stringer
 }
@@ -24,6 +26,9 @@ type Serie[T, U ScalerConstraint] struct {
 	Points []Point[T, U]
 
 	Renderer[T, U]
+
+	formatX func(T) string
+	formatY func(U) string
 }
 
 func (s Serie[T, U]) Id() string {
@@ -62,10 +67,105 @@ func (s Serie[T, U]) Render() svg.Element {
 	return s.Renderer.Render(s)
 }
 
+// withFormat makes the serie interactive: its renderer attaches the formatted
+// values of each point to the elements it creates.
+func (s Serie[T, U]) withFormat(fx func(T) string, fy func(U) string) Data {
+	s.formatX = fx
+	s.formatY = fy
+	return s
+}
+
+func (s Serie[T, U]) interactive() bool {
+	return s.formatX != nil && s.formatY != nil
+}
+
+func (s Serie[T, U]) pointData(pt Point[T, U]) []svg.Datum {
+	if !s.interactive() {
+		return nil
+	}
+	return []svg.Datum{
+		{Name: "x", Value: dataValue(s.formatX(pt.X))},
+		{Name: "y", Value: dataValue(s.formatY(pt.Y))},
+	}
+}
+
+func (s Serie[T, U]) subData(parent, pt Point[T, U]) []svg.Datum {
+	if !s.interactive() {
+		return nil
+	}
+	return []svg.Datum{
+		{Name: "x", Value: dataValue(s.formatX(parent.X))},
+		{Name: "y", Value: dataValue(s.formatY(pt.Y))},
+		{Name: "label", Value: dataValue(s.formatX(pt.X))},
+	}
+}
+
+// crossData is the counterpart of subData for series having their categories
+// on the y axis: the label of the sub point is its y value.
+func (s Serie[T, U]) crossData(parent, pt Point[T, U]) []svg.Datum {
+	if !s.interactive() {
+		return nil
+	}
+	return []svg.Datum{
+		{Name: "x", Value: dataValue(s.formatX(pt.X))},
+		{Name: "y", Value: dataValue(s.formatY(parent.Y))},
+		{Name: "label", Value: dataValue(s.formatY(pt.Y))},
+	}
+}
+
+// renderHits gives invisible markers on each point of the serie to be used as
+// hover targets by renderers that only draw a path.
+func (s Serie[T, U]) renderHits() svg.Element {
+	if !s.interactive() {
+		return nil
+	}
+	grp := classGroup("hits")
+	for _, pt := range s.Points {
+		if isNaN(pt.Y) {
+			continue
+		}
+		var el svg.Circle
+		el.Pos = svg.NewPos(s.X.Scale(pt.X), s.Y.Scale(pt.Y))
+		el.Radius = DefaultSize
+		el.Fill = svg.NewFill("transparent")
+		el.Data = s.pointData(pt)
+		grp.Append(el.AsElement())
+	}
+	return grp.AsElement()
+}
+
+var xmlEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	`\`, "&#92;",
+)
+
+func dataValue(str string) string {
+	return xmlEscaper.Replace(str)
+}
+
+func (s Serie[T, U]) Swatch() Swatch {
+	var sw Swatch
+	if r, ok := s.Renderer.(interface{ Swatch() Swatch }); ok {
+		sw = r.Swatch()
+	}
+	sw.Title = s.Title
+	return sw
+}
+
 type Point[T, U ScalerConstraint] struct {
 	X   T
 	Y   U
 	Sub []Point[T, U]
+
+	// Value is an additional value attached to the point for renderers that
+	// need more than its position (eg: the value of a heatmap cell).
+	Value float64
+	// Size is the size given to the marker of the point by renderers sizing
+	// their markers.
+	Size float64
 }
 
 func NumberPoint(x, y float64) Point[float64, float64] {
@@ -82,6 +182,16 @@ func TimePoint(x time.Time, y float64) Point[time.Time, float64] {
 	}
 }
 
+// OHLCPoint creates a point carrying the open, high, low and close values
+// of a period as sub points. The value of the point itself is the open value.
+func OHLCPoint(x time.Time, open, high, low, close float64) Point[time.Time, float64] {
+	pt := TimePoint(x, open)
+	for _, v := range []float64{open, high, low, close} {
+		pt.Sub = append(pt.Sub, TimePoint(x, v))
+	}
+	return pt
+}
+
 func CategoryPoint(x string, y float64) Point[string, float64] {
 	return Point[string, float64]{
 		X: x,
@@ -94,10 +204,16 @@ func CategoryPoint(x string, y float64) Point[string, float64] {
 // }
 
 func (p Point[T, U]) Reverse() Point[U, T] {
-	return Point[U, T]{
-		X: p.Y,
-		Y: p.X,
+	pt := Point[U, T]{
+		X:     p.Y,
+		Y:     p.X,
+		Value: p.Value,
+		Size:  p.Size,
 	}
+	for i := range p.Sub {
+		pt.Sub = append(pt.Sub, p.Sub[i].Reverse())
+	}
+	return pt
 }
 
 func (p Point[T, U]) Depth() int {
@@ -117,3 +233,28 @@ func (p Point[T, U]) Depth() int {
 func (p Point[T, U]) isLeaf() bool {
 	return len(p.Sub) == 0
 }
+
+// bounds gives the lower and upper bounds of a point having three sub points:
+// its value, its lower bound and its upper bound.
+func (p Point[T, U]) bounds() (lower, upper U, ok bool) {
+	if len(p.Sub) != 3 {
+		return
+	}
+	return p.Sub[1].Y, p.Sub[2].Y, true
+}
+
+// xbounds gives the bounds of a point whose value is given by X (eg: the
+// reversed points of a horizontal serie).
+func (p Point[T, U]) xbounds() (lower, upper T, ok bool) {
+	if len(p.Sub) != 3 {
+		return
+	}
+	return p.Sub[1].X, p.Sub[2].X, true
+}
+
+func (p Point[T, U]) ohlc() (open, high, low, close U, ok bool) {
+	if len(p.Sub) < 4 {
+		return
+	}
+	return p.Sub[0].Y, p.Sub[1].Y, p.Sub[2].Y, p.Sub[3].Y, true
+}
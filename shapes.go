@@ -6,41 +6,50 @@ import (
 
 var DefaultSize float64 = 4
 
-type PointFunc func(svg.Pos) svg.Element
+// PointFunc gives the marker of a point centered on pos. size gives the
+// width of the marker.
+type PointFunc func(pos svg.Pos, size float64, fill string) svg.Element
 
-func GetCircle(pos svg.Pos) svg.Element {
+func GetCircle(pos svg.Pos, size float64, fill string) svg.Element {
 	var el svg.Circle
 	el.Pos = pos
-	el.Fill = svg.NewFill(currentColour)
-	el.Radius = DefaultSize / 2
+	el.Fill = svg.NewFill(markerFill(fill))
+	el.Radius = size / 2
 	return el.AsElement()
 }
 
-func GetSquare(pos svg.Pos) svg.Element {
-	half := DefaultSize / 2
+func GetSquare(pos svg.Pos, size float64, fill string) svg.Element {
+	half := size / 2
 	pos.X -= half
 	pos.Y -= half
 
 	var el svg.Rect
 	el.Pos = pos
-	el.Dim = svg.NewDim(DefaultSize, DefaultSize)
-	el.Fill = svg.NewFill(currentColour)
+	el.Dim = svg.NewDim(size, size)
+	el.Fill = svg.NewFill(markerFill(fill))
 
 	return el.AsElement()
 }
 
-func GetDiamond(pos svg.Pos) svg.Element {
-	half := DefaultSize / 2
+func GetDiamond(pos svg.Pos, size float64, fill string) svg.Element {
+	half := size / 2
 	pos.X -= half
 	pos.Y -= half
 
 	var el svg.Rect
 	el.Pos = pos
-	el.Dim = svg.NewDim(DefaultSize, DefaultSize)
-	el.Fill = svg.NewFill(currentColour)
+	el.Dim = svg.NewDim(size, size)
+	el.Fill = svg.NewFill(markerFill(fill))
 	el.Transform.RA = 45
 	el.Transform.RX = pos.X + half
 	el.Transform.RY = pos.Y + half
 
 	return el.AsElement()
 }
+
+func markerFill(fill string) string {
+	if fill == "" {
+		return currentColour
+	}
+	return fill
+}
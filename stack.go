@@ -0,0 +1,93 @@
+package charts
+
+type StackOffset int
+
+const (
+	StackZero StackOffset = iota
+	StackExpand
+	StackWiggle
+)
+
+// StackAreas stacks, in the order they are given, the series drawn by a
+// StackedAreaRenderer. Only the series having the same offset are stacked
+// together. Once stacked, the Y of a point gives the top of its layer and its
+// Value gives the baseline of the layer. Points of the layers are matched by
+// their X value; a layer without value at a given X counts for nothing.
+func StackAreas[T, U ScalerConstraint](series []Serie[T, U]) []Serie[T, U] {
+	var (
+		groups  = make(map[StackOffset][]int)
+		offsets []StackOffset
+	)
+	for i := range series {
+		r, ok := series[i].Renderer.(StackedAreaRenderer[T, U])
+		if !ok {
+			continue
+		}
+		if _, ok := groups[r.Offset]; !ok {
+			offsets = append(offsets, r.Offset)
+		}
+		groups[r.Offset] = append(groups[r.Offset], i)
+	}
+	for _, off := range offsets {
+		stackLayers(series, groups[off], off)
+	}
+	return series
+}
+
+func stackLayers[T, U ScalerConstraint](series []Serie[T, U], layers []int, offset StackOffset) {
+	values := make(map[T][]float64)
+	for j, i := range layers {
+		for _, pt := range series[i].Points {
+			vs, ok := values[pt.X]
+			if !ok {
+				vs = make([]float64, len(layers))
+				values[pt.X] = vs
+			}
+			if v, ok := any(pt.Y).(float64); ok && !isNaN(v) {
+				vs[j] += v
+			}
+		}
+	}
+	bases := make(map[T][]float64)
+	for x, vs := range values {
+		var (
+			total float64
+			base  float64
+			list  = make([]float64, len(vs)+1)
+		)
+		for _, v := range vs {
+			total += v
+		}
+		switch offset {
+		case StackExpand:
+			if total != 0 {
+				for j := range vs {
+					vs[j] /= total
+				}
+			}
+		case StackWiggle:
+			n := float64(len(vs))
+			for j, v := range vs {
+				base -= (n - float64(j)) * v
+			}
+			base /= n + 1
+		}
+		list[0] = base
+		for j, v := range vs {
+			list[j+1] = list[j] + v
+		}
+		bases[x] = list
+	}
+	for j, i := range layers {
+		points := make([]Point[T, U], len(series[i].Points))
+		for k, pt := range series[i].Points {
+			list := bases[pt.X]
+			if y, ok := any(list[j+1]).(U); ok {
+				pt.Y = y
+			}
+			pt.Value = list[j]
+			points[k] = pt
+		}
+		series[i].Points = points
+	}
+}
@@ -0,0 +1,50 @@
+package charts
+
+import (
+	"testing"
+)
+
+func TestStackAreas(t *testing.T) {
+	tests := []struct {
+		Offset StackOffset
+		Want   [][2]float64
+	}{
+		{
+			Offset: StackZero,
+			Want:   [][2]float64{{0, 1}, {1, 4}, {0, 2}, {2, 4}},
+		},
+		{
+			Offset: StackExpand,
+			Want:   [][2]float64{{0, 0.25}, {0.25, 1}, {0, 0.5}, {0.5, 1}},
+		},
+		{
+			Offset: StackWiggle,
+			Want:   [][2]float64{{-5.0 / 3, -2.0 / 3}, {-2.0 / 3, 7.0 / 3}, {-2, 0}, {0, 2}},
+		},
+	}
+	for _, c := range tests {
+		rdr := StackedAreaRenderer[float64, float64]{Offset: c.Offset}
+		series := []Serie[float64, float64]{
+			{Points: []Point[float64, float64]{NumberPoint(0, 1), NumberPoint(1, 2)}, Renderer: rdr},
+			{Points: []Point[float64, float64]{NumberPoint(0, 3), NumberPoint(1, 2)}, Renderer: rdr},
+		}
+		series = StackAreas(series)
+
+		var got [][2]float64
+		for i := range series[0].Points {
+			for _, s := range series {
+				got = append(got, [2]float64{s.Points[i].Value, s.Points[i].Y})
+			}
+		}
+		for i := range c.Want {
+			if !almostEqual(got[i][0], c.Want[i][0]) || !almostEqual(got[i][1], c.Want[i][1]) {
+				t.Errorf("offset %d: layer mismatched: want %v, got %v", c.Offset, c.Want[i], got[i])
+			}
+		}
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	d := a - b
+	return d > -1e-9 && d < 1e-9
+}
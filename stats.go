@@ -0,0 +1,130 @@
+package charts
+
+import (
+	"math"
+	"sort"
+)
+
+// Summary describes the distribution of a set of values. Min and Max are the
+// ends of the whiskers: the most extreme values within 1.5 IQR of the
+// quartiles. The values beyond are the outliers.
+type Summary struct {
+	Min      float64
+	Q1       float64
+	Median   float64
+	Q3       float64
+	Max      float64
+	Outliers []float64
+	Values   []float64
+}
+
+func Summarize(values []float64) Summary {
+	var sum Summary
+	sum.Values = sortedValues(values)
+	if len(sum.Values) == 0 {
+		return sum
+	}
+	sum.Q1 = quantile(sum.Values, 0.25)
+	sum.Median = quantile(sum.Values, 0.5)
+	sum.Q3 = quantile(sum.Values, 0.75)
+
+	var (
+		iqr  = sum.Q3 - sum.Q1
+		low  = sum.Q1 - 1.5*iqr
+		high = sum.Q3 + 1.5*iqr
+	)
+	sum.Min, sum.Max = sum.Q1, sum.Q3
+	for _, v := range sum.Values {
+		if v < low || v > high {
+			sum.Outliers = append(sum.Outliers, v)
+			continue
+		}
+		sum.Min = math.Min(sum.Min, v)
+		sum.Max = math.Max(sum.Max, v)
+	}
+	return sum
+}
+
+// Density estimates the density of the values at count points evenly spaced
+// between the lowest and the highest values with a gaussian kernel.
+func (s Summary) Density(count int) ([]float64, []float64) {
+	if len(s.Values) == 0 || count < 2 {
+		return nil, nil
+	}
+	var (
+		fst  = s.Values[0]
+		lst  = s.Values[len(s.Values)-1]
+		band = s.bandwidth()
+		pos  = make([]float64, count)
+		dens = make([]float64, count)
+		norm = 1 / (float64(len(s.Values)) * band * math.Sqrt(2*math.Pi))
+	)
+	for i := range pos {
+		pos[i] = fst + (lst-fst)*float64(i)/float64(count-1)
+		for _, v := range s.Values {
+			u := (pos[i] - v) / band
+			dens[i] += math.Exp(-u * u / 2)
+		}
+		dens[i] *= norm
+	}
+	return pos, dens
+}
+
+// bandwidth gives the bandwidth of the kernel according to the Silverman's
+// rule of thumb.
+func (s Summary) bandwidth() float64 {
+	var (
+		dev    = stdDev(s.Values)
+		spread = (s.Q3 - s.Q1) / 1.34
+	)
+	if spread > 0 && spread < dev {
+		dev = spread
+	}
+	if dev <= 0 {
+		return 1
+	}
+	return 0.9 * dev * math.Pow(float64(len(s.Values)), -0.2)
+}
+
+func sortedValues(values []float64) []float64 {
+	list := make([]float64, 0, len(values))
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		list = append(list, v)
+	}
+	sort.Float64s(list)
+	return list
+}
+
+func stdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	var sum float64
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+// quantile expects sorted values and interpolates linearly between the two
+// closest ranks.
+func quantile(values []float64, q float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	var (
+		pos  = q * float64(len(values)-1)
+		low  = int(math.Floor(pos))
+		high = int(math.Ceil(pos))
+	)
+	return values[low] + (values[high]-values[low])*(pos-float64(low))
+}
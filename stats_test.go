@@ -0,0 +1,34 @@
+package charts
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	values := []float64{7, 1, 3, 2, 5, 4, 6, 8, 40, math.NaN()}
+	sum := Summarize(values)
+	if len(sum.Values) != 9 {
+		t.Fatalf("NaN should be ignored: got %d values", len(sum.Values))
+	}
+	want := Summary{Min: 1, Q1: 3, Median: 5, Q3: 7, Max: 8}
+	if sum.Min != want.Min || sum.Q1 != want.Q1 || sum.Median != want.Median || sum.Q3 != want.Q3 || sum.Max != want.Max {
+		t.Errorf("summary mismatched: want %+v, got %+v", want, sum)
+	}
+	if len(sum.Outliers) != 1 || sum.Outliers[0] != 40 {
+		t.Errorf("expected 40 as outlier, got %v", sum.Outliers)
+	}
+}
+
+func TestSummaryDensity(t *testing.T) {
+	sum := Summarize([]float64{1, 2, 2, 3, 3, 3, 4, 4, 5})
+	pos, dens := sum.Density(9)
+	if len(pos) != 9 || pos[0] != 1 || pos[8] != 5 {
+		t.Fatalf("unexpected positions: %v", pos)
+	}
+	for i := 1; i < 4; i++ {
+		if dens[i] < dens[i-1] {
+			t.Errorf("density should increase up to the mode: %v", dens)
+		}
+	}
+}
@@ -83,8 +83,18 @@ func (s Style) Rect(w, h float64) svg.Rect {
 	return rec
 }
 
+func (s Style) Swatch() Swatch {
+	return Swatch{
+		LineType:  s.LineType,
+		LineColor: s.LineColor,
+		LineWidth: s.LineWidth,
+		Fill:      s.FillList.Curr(),
+		Opacity:   s.FillOpacity,
+	}
+}
+
 func (s Style) Text(str string) svg.Text {
-	txt := svg.NewText(str)
+	txt := newText(str)
 	txt.Baseline = "middle"
 	txt.Fill = svg.NewFill(s.FontColor)
 	txt.Font = svg.NewFont(s.FontSize)
@@ -135,6 +145,37 @@ func (s Style) basePath(fill string) svg.Path {
 	return pat
 }
 
+// ErrorStyle gives the look of the bounds of the points: a band around the
+// line of a LinearRenderer or whiskers on bars and markers. The bounds are
+// only drawn when Visible is set. The color of the renderer is used when no
+// color is given.
+type ErrorStyle struct {
+	Visible bool
+	Color   string
+	Opacity float64
+	Width   float64
+	Cap     float64
+}
+
+func (e ErrorStyle) withDefaults(color string) ErrorStyle {
+	if e.Color == "" {
+		e.Color = color
+	}
+	if e.Color == "" || e.Color == ColorNone {
+		e.Color = ColorBlack
+	}
+	if e.Opacity <= 0 {
+		e.Opacity = 0.25
+	}
+	if e.Width <= 0 {
+		e.Width = 1
+	}
+	if e.Cap <= 0 {
+		e.Cap = DefaultSize * 2
+	}
+	return e
+}
+
 type Padding struct {
 	Top    float64
 	Right  float64
@@ -176,6 +217,12 @@ func (p Padding) Vertical() float64 {
 	return p.Top + p.Bottom
 }
 
+// newText creates a text element escaping the characters that can not be
+// written as is in a svg document.
+func newText(str string) svg.Text {
+	return svg.NewText(xmlEscaper.Replace(str))
+}
+
 func classGroup(class ...string) svg.Group {
 	var grp svg.Group
 	grp.Class = append(grp.Class, class...)